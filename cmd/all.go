@@ -1,39 +1,195 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"strings"
+	"strconv"
+	"time"
 
+	"github.com/segwin/adventofcode-2020/internal/cache"
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/segwin/adventofcode-2020/internal/logging"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
 	"github.com/spf13/cobra"
 )
 
-var (
-	inputDirectory string
-)
+// dayOutcome holds the outcome of solving a single day.
+type dayOutcome struct {
+	path     string // input file
+	result   solutions.Result
+	duration time.Duration
+	err      error
+	cached   bool // answers were read from the cache rather than solved
+}
+
+// solveOptions holds the settings shared by every day solved by solveAll.
+type solveOptions struct {
+	cfg      config.Config
+	inputDir string
+	timeout  time.Duration
+	prof     profileFlags
+	cache    *dayCache // nil to always solve days
+
+	// inputSet & timeoutSet are true if inputDir & timeout were set with flags, which
+	// take precedence over the per-day configuration
+	inputSet, timeoutSet bool
+}
+
+func newAllCommand(year int, cfg config.Config) *cobra.Command {
+	var (
+		opts     = solveOptions{cfg: cfg}
+		output   string
+		jobs     int
+		noCache  bool
+		cacheDir string
+	)
 
-func newAllCommand() *cobra.Command {
 	allCmd := &cobra.Command{
 		Use:   "all",
 		Short: "Run all solutions back-to-back",
-		Args:  cobra.NoArgs,
-		Run: func(cmd *cobra.Command, _ []string) {
-			for i, solution := range solutionsList {
-				day := i + 1
-				inputFile := strings.Join([]string{inputDirectory, fmt.Sprintf("day%d", day), "input"}, "/")
-
-				divider := "----------"
-				if day >= 10 {
-					divider += "-"
+		Long: `Run all solutions back-to-back, then print a summary of the days that passed, failed
+(including panics) or were skipped for lack of an input file. A failing day doesn't stop
+the others from running, but the command fails if any day did. With a machine-readable
+--output format, the summary is written to standard error.
+
+Days whose input file & solution code haven't changed since they were last fully solved
+are skipped, reusing their cached answers (see the cache command). Use --no-cache to
+solve every day regardless; the cache is also bypassed when profiling.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if jobs < 1 {
+				return fmt.Errorf("%w (--jobs must be at least 1, got %d)", ErrInvalidFlag, jobs)
+			} else if jobs > 1 && opts.prof.Enabled() {
+				return fmt.Errorf("%w (profiling requires --jobs 1)", ErrInvalidFlag)
+			}
+
+			opts.inputSet, opts.timeoutSet = cmd.Flags().Changed("input"), cmd.Flags().Changed("timeout")
+
+			logger := logging.FromContext(cmd.Context()).With(strconv.Itoa(year))
+			writer, err := newResultWriter(output, cmd.OutOrStdout(), logger, true)
+			if err != nil {
+				return err
+			}
+
+			if !noCache && !opts.prof.Enabled() {
+				opts.cache = newDayCache(cacheDir)
+			}
+
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			entries := solutions.List(year)
+			outcomes := solveAll(ctx, entries, jobs, opts)
+
+			// report outcomes in day order, as they become available
+			summaries := make([]daySummary, 0, len(entries))
+			for i, outcome := range outcomes {
+				day := entries[i].Day
+
+				o := <-outcome
+				summary := summarize(entries[i], o)
+				summaries = append(summaries, summary)
+
+				if summary.Status != daySkipped && !o.cached {
+					if err := recordHistory(cmd, year, day, o.path, o.result, o.duration, o.err); err != nil {
+						return err
+					}
 				}
 
-				fmt.Printf("%s\n  Day %d\n%s\n", divider, day, divider)
-				solution.Run(cmd.Context(), inputFile)
-				fmt.Println()
+				if err := writer.Write(day, o); err != nil {
+					return err
+				}
+			}
+
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+
+			summaryOut := cmd.OutOrStdout()
+			if output != textOutput {
+				summaryOut = cmd.ErrOrStderr() // keep the output machine-readable
 			}
+
+			return writeSummary(summaryOut, summaries)
 		},
 	}
 
-	allCmd.Flags().StringVarP(&inputDirectory, "input", "i", "inputs", "Path to directory containing all input files, structured as <dir>/day<N>/input")
+	allCmd.Flags().StringVarP(&opts.inputDir, "input", "i", cfg.Inputs, "Path to directory containing all input files, structured as <dir>/<year>/day<N>/input")
+	allCmd.Flags().StringVarP(&output, "output", "o", cfg.Output, fmt.Sprintf("Output format, one of %v", outputFormats))
+	allCmd.Flags().IntVarP(&jobs, "jobs", "j", 1, "Number of days to solve concurrently")
+	allCmd.Flags().DurationVar(&opts.timeout, "timeout", cfg.Timeout, "Maximum time allowed to solve each day, or 0 for no limit")
+	allCmd.Flags().BoolVar(&noCache, "no-cache", false, "Solve every day, ignoring & not updating the result cache")
+	allCmd.Flags().StringVar(&cacheDir, "cache-dir", cache.DefaultDir, "Path to the result cache directory")
+	addProfileFlags(allCmd, &opts.prof)
 
 	return allCmd
 }
+
+// solveAll solves the given entries using the given number of concurrent workers,
+// allowing each entry up to opts.timeout to be solved (if non-zero). Per-day input files
+// & timeouts configured in opts.cfg are honoured (see dayInputFile & dayTimeout), each day
+// is profiled as requested by opts.prof, which requires a single worker, and days found
+// in opts.cache aren't solved again. It returns one channel per entry (in the same order),
+// each receiving exactly one outcome once that entry has been solved. If ctx is done before
+// an entry could be started, its outcome holds ctx's error instead.
+func solveAll(ctx context.Context, entries []solutions.Entry, jobs int, opts solveOptions) (outcomes []chan dayOutcome) {
+	outcomes = make([]chan dayOutcome, len(entries))
+	for i := range outcomes {
+		outcomes[i] = make(chan dayOutcome, 1) // buffered so workers never block on a slow reader
+	}
+
+	indices := make(chan int)
+	go func() {
+		defer close(indices)
+
+		for i := range entries {
+			select {
+			case indices <- i:
+			case <-ctx.Done():
+				// the remaining days won't be solved, but their outcomes are still awaited
+				for ; i < len(entries); i++ {
+					outcomes[i] <- dayOutcome{err: solutions.CheckContext(ctx)}
+				}
+
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < jobs; i++ {
+		go func() {
+			for i := range indices {
+				outcomes[i] <- solveDay(ctx, entries[i], opts)
+			}
+		}()
+	}
+
+	return outcomes
+}
+
+// solveDay solves a single entry for solveAll.
+func solveDay(ctx context.Context, entry solutions.Entry, opts solveOptions) dayOutcome {
+	ctx = dayContext(ctx, entry.Year, entry.Day)
+	path := dayInputFile(opts.cfg, opts.inputDir, opts.inputSet, entry.Year, entry.Day)
+
+	key, o, ok := opts.cache.lookup(ctx, entry, path)
+	if ok {
+		return o
+	}
+
+	o = dayOutcome{path: path}
+
+	ctx, stopProfile, err := opts.prof.start(ctx, fmt.Sprintf("day%d", entry.Day))
+	if err != nil {
+		o.err = err
+		return o
+	}
+
+	o.result, o.duration, o.err = solveFile(ctx, entry.Solution, o.path, dayTimeout(opts.cfg, opts.timeout, opts.timeoutSet, entry.Year, entry.Day))
+	if err := stopProfile(); err != nil && o.err == nil {
+		o.err = err
+	}
+
+	opts.cache.store(ctx, key, entry, o)
+	return o
+}
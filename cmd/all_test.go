@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+// sleepingSolution answers with its delay after waiting for it, or until ctx is done.
+type sleepingSolution struct {
+	delay time.Duration
+}
+
+func (s *sleepingSolution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	return solutions.Parts{
+		func(ctx context.Context) solutions.Part {
+			select {
+			case <-time.After(s.delay):
+				return solutions.Part{Answer: s.delay.String()}
+			case <-ctx.Done():
+				return solutions.Part{Err: ctx.Err()}
+			}
+		},
+	}, nil
+}
+
+func TestSolveAll(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		delays   []time.Duration
+		jobs     int
+		canceled bool // cancel the context before solving any day
+		cancel   bool // cancel the context once the first day has been solved
+
+		// outputs
+		expected []string // answer or error of each day, in order
+	}
+
+	inputDir, err := ioutil.TempDir("", "aoc-all")
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(inputDir) })
+
+	for day := 1; day <= 4; day++ {
+		path := dayFile(inputDir, 2020, day, "input")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		if err := ioutil.WriteFile(path, []byte("input\n"), 0644); err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		entries := make([]solutions.Entry, len(cfg.delays))
+		for i, delay := range cfg.delays {
+			entries[i] = solutions.Entry{Year: 2020, Day: i + 1, Solution: &sleepingSolution{delay: delay}}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		if cfg.canceled {
+			cancel()
+		}
+
+		outcomes := solveAll(ctx, entries, cfg.jobs, solveOptions{inputDir: inputDir})
+
+		var got []string
+		for i, outcome := range outcomes {
+			select {
+			case o := <-outcome:
+				switch {
+				case o.err != nil:
+					got = append(got, o.err.Error())
+				case o.result.Parts[0].Err != nil:
+					got = append(got, o.result.Parts[0].Err.Error())
+				default:
+					got = append(got, fmt.Sprint(o.result.Parts[0].Answer))
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("Timed out waiting for day %d", i+1)
+			}
+
+			if cfg.cancel {
+				cancel()
+			}
+		}
+
+		if diff := cmp.Diff(cfg.expected, got); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+	}
+
+	tests := map[string]Test{
+		"ordered: sequential": {
+			delays:   []time.Duration{20 * time.Millisecond, time.Millisecond, 10 * time.Millisecond},
+			jobs:     1,
+			expected: []string{"20ms", "1ms", "10ms"},
+		},
+		"ordered: concurrent": {
+			delays:   []time.Duration{20 * time.Millisecond, time.Millisecond, 10 * time.Millisecond, 5 * time.Millisecond},
+			jobs:     3,
+			expected: []string{"20ms", "1ms", "10ms", "5ms"},
+		},
+		"canceled: before start": {
+			delays:   []time.Duration{time.Hour, time.Hour},
+			jobs:     1,
+			canceled: true,
+			expected: []string{context.Canceled.Error(), context.Canceled.Error()},
+		},
+		"canceled: while solving": {
+			delays:   []time.Duration{time.Millisecond, time.Hour, time.Hour, time.Hour},
+			jobs:     1,
+			cancel:   true,
+			expected: []string{"1ms", context.Canceled.Error(), context.Canceled.Error(), context.Canceled.Error()},
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
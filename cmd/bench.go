@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"runtime"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ErrRegression  = errors.New("performance regression detected")
+	ErrBenchFailed = errors.New("some stages failed")
+)
+
+// parseStage is the benchmark stage measuring how long it takes to parse the input.
+// Each part is measured separately in its own stage (e.g. "part1").
+const parseStage = "parse"
+
+// benchStats holds the benchmark results for a single stage of a day.
+type benchStats struct {
+	Day    int           `json:"day"`
+	Stage  string        `json:"stage"`
+	Runs   int           `json:"runs"`
+	Min    time.Duration `json:"min_ns"`
+	Median time.Duration `json:"median_ns"`
+	P95    time.Duration `json:"p95_ns"`
+	Allocs uint64        `json:"allocs_per_run"`
+	Bytes  uint64        `json:"bytes_per_run"`
+	Error  string        `json:"error,omitempty"` // set if the stage failed, without statistics
+}
+
+func newBenchCommand(year int, cfg config.Config) *cobra.Command {
+	var (
+		inputDir  string
+		runs      int
+		baseline  string
+		save      string
+		threshold float64
+		part      int
+	)
+
+	benchCmd := &cobra.Command{
+		Use:   "bench [days...]",
+		Short: "Benchmark the given days (or all days if none are given)",
+		Long: `Solve each of the given days (or all days if none are given) repeatedly and
+report the min, median & 95th percentile durations along with the allocations made
+per run. Input files are read once up front so that only solving is measured.
+
+Parsing the input and solving each part are measured separately, as the "parse",
+"part1", "part2", etc. stages. Use --part to only measure a single part.
+
+Results can be saved with --save and later compared against with --baseline: any
+stage whose median duration or bytes allocated grew by more than --threshold
+percent is flagged as a regression.
+
+A stage that fails or panics is reported as such (along with the following parts if
+parsing failed) without stopping the benchmark, but the command fails once done. Parts
+that aren't implemented yet are skipped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runs < 1 {
+				return fmt.Errorf("%w (--runs must be at least 1, got %d)", ErrInvalidFlag, runs)
+			}
+
+			entries, err := selectEntries(year, args)
+			if err != nil {
+				return err
+			}
+
+			baselineStats, err := readBaseline(baseline)
+			if err != nil {
+				return err
+			}
+
+			var parts []int
+			if part != 0 {
+				parts = append(parts, part)
+			}
+
+			var allStats []benchStats
+			for _, entry := range entries {
+				day := entry.Day
+
+				inputData, err := ioutil.ReadFile(dayInputFile(cfg, inputDir, cmd.Flags().Changed("input"), year, day))
+				if err != nil {
+					return fmt.Errorf("day %d: failed to read input file (%w)", day, err)
+				}
+
+				stats, err := benchDay(cmd.Context(), entry.Solution, inputData, runs, parts...)
+				if err != nil {
+					return fmt.Errorf("day %d: %w", day, err)
+				}
+
+				for i := range stats {
+					stats[i].Day = day
+				}
+
+				allStats = append(allStats, stats...)
+			}
+
+			regressions := printBenchStats(cmd, allStats, baselineStats, threshold)
+
+			failed := 0
+			for _, stats := range allStats {
+				if stats.Error != "" {
+					failed++
+				}
+			}
+
+			if save != "" {
+				data, err := json.MarshalIndent(allStats, "", "  ")
+				if err != nil {
+					return err
+				}
+
+				if err := ioutil.WriteFile(save, append(data, '\n'), 0644); err != nil {
+					return fmt.Errorf("failed to save results (%w)", err)
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%w (%d stages)", ErrBenchFailed, failed)
+			}
+
+			if regressions > 0 {
+				return fmt.Errorf("%w (%d stages)", ErrRegression, regressions)
+			}
+
+			return nil
+		},
+	}
+
+	benchCmd.Flags().StringVarP(&inputDir, "input", "i", cfg.Inputs, "Path to directory containing all input files, structured as <dir>/<year>/day<N>/input")
+	benchCmd.Flags().IntVarP(&runs, "runs", "n", 10, "Number of times to solve each day")
+	benchCmd.Flags().IntVarP(&part, "part", "p", 0, "Only measure the given part (e.g. 1 or 2) after parsing, or 0 to measure all parts")
+	benchCmd.Flags().StringVar(&baseline, "baseline", "", "Path to previously saved results to compare against")
+	benchCmd.Flags().StringVar(&save, "save", "", "Path to save the results to, as JSON")
+	benchCmd.Flags().Float64Var(&threshold, "threshold", 10, "Percentage increase over the baseline above which a stage is flagged as a regression")
+
+	return benchCmd
+}
+
+// benchDay parses the given input & solves the given parts (or all parts if none are
+// given) the requested number of times, gathering duration & allocation statistics
+// for each stage. Panics are recovered as in solutions.Solve, and a stage that fails is
+// returned with its Error set rather than as an error. If parsing fails, no part is
+// measured. Parts that aren't implemented are skipped.
+func benchDay(ctx context.Context, solution solutions.Solution, inputData []byte, runs int, parts ...int) (allStats []benchStats, err error) {
+	var partFuncs solutions.Parts
+	stats, err := measure(runs, func() (err error) {
+		partFuncs, err = solutions.Parse(ctx, solution, bytes.NewReader(inputData))
+		return err
+	})
+	if err != nil {
+		return []benchStats{{Stage: parseStage, Error: err.Error()}}, nil
+	}
+
+	stats.Stage = parseStage
+	allStats = append(allStats, stats)
+
+	if len(parts) == 0 {
+		for i := range partFuncs {
+			parts = append(parts, i+1)
+		}
+	}
+
+	for _, number := range parts {
+		if number < 1 || number > len(partFuncs) {
+			return nil, fmt.Errorf("%w (%d, expected 1 to %d)", solutions.ErrInvalidPart, number, len(partFuncs))
+		}
+
+		partFunc := partFuncs[number-1]
+		stats, err := measure(runs, func() error {
+			return solutions.SolvePart(ctx, partFunc).Err
+		})
+
+		stage := fmt.Sprintf("part%d", number)
+		switch {
+		case errors.Is(err, solutions.ErrNotImplemented):
+			continue
+		case err != nil:
+			allStats = append(allStats, benchStats{Stage: stage, Error: err.Error()})
+			continue
+		}
+
+		stats.Stage = stage
+		allStats = append(allStats, stats)
+	}
+
+	return allStats, nil
+}
+
+// measure calls fn the requested number of times, gathering duration & allocation
+// statistics. It stops at the first error returned by fn.
+func measure(runs int, fn func() error) (stats benchStats, err error) {
+	durations := make([]time.Duration, runs)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	for i := range durations {
+		start := time.Now()
+		if err := fn(); err != nil {
+			return benchStats{}, err
+		}
+
+		durations[i] = time.Since(start)
+	}
+
+	runtime.ReadMemStats(&after)
+
+	stats = summarise(durations)
+	stats.Allocs = (after.Mallocs - before.Mallocs) / uint64(runs)
+	stats.Bytes = (after.TotalAlloc - before.TotalAlloc) / uint64(runs)
+
+	return stats, nil
+}
+
+// summarise computes the min, median & 95th percentile of the given durations.
+func summarise(durations []time.Duration) benchStats {
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	n := len(sorted)
+
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	p95 := sorted[int(math.Ceil(0.95*float64(n)))-1]
+
+	return benchStats{Runs: n, Min: sorted[0], Median: median, P95: p95}
+}
+
+// benchKey identifies a single stage of a day's benchmark.
+type benchKey struct {
+	day   int
+	stage string
+}
+
+// readBaseline reads previously saved benchmark results, mapped by day & stage. An
+// empty path returns no baseline.
+func readBaseline(path string) (baseline map[benchKey]benchStats, err error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline (%w)", err)
+	}
+
+	var allStats []benchStats
+	if err := json.Unmarshal(data, &allStats); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline (%w)", err)
+	}
+
+	baseline = map[benchKey]benchStats{}
+	for _, stats := range allStats {
+		baseline[benchKey{day: stats.Day, stage: stats.Stage}] = stats
+	}
+
+	return baseline, nil
+}
+
+// isRegression returns true if the current stats are worse than the baseline by more
+// than the given percentage threshold.
+func isRegression(current, baseline benchStats, threshold float64) bool {
+	exceeds := func(current, baseline float64) bool {
+		return baseline > 0 && (current-baseline)/baseline*100 > threshold
+	}
+
+	return exceeds(float64(current.Median), float64(baseline.Median)) || exceeds(float64(current.Bytes), float64(baseline.Bytes))
+}
+
+// printBenchStats writes the given stats as a table, comparing them to the baseline
+// if there is one. The number of regressions found is returned.
+func printBenchStats(cmd *cobra.Command, allStats []benchStats, baseline map[benchKey]benchStats, threshold float64) (regressions int) {
+	table := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	header := "DAY\tSTAGE\tRUNS\tMIN\tMEDIAN\tP95\tALLOCS/RUN\tBYTES/RUN\t"
+	if baseline != nil {
+		header += "BASELINE\tCHANGE\tSTATUS\t"
+	}
+
+	fmt.Fprintln(table, header)
+
+	for _, stats := range allStats {
+		if stats.Error != "" {
+			fmt.Fprintf(table, "%d\t%s\t-\t-\t-\t-\t-\t-\t", stats.Day, stats.Stage)
+			if baseline != nil {
+				fmt.Fprint(table, "-\t-\tFAILED\t")
+			}
+
+			fmt.Fprintf(table, "\t%s\n", stats.Error)
+			continue
+		}
+
+		fmt.Fprintf(table, "%d\t%s\t%d\t%s\t%s\t%s\t%d\t%d\t", stats.Day, stats.Stage, stats.Runs, round(stats.Min), round(stats.Median), round(stats.P95), stats.Allocs, stats.Bytes)
+
+		if baseline != nil {
+			previous, ok := baseline[benchKey{day: stats.Day, stage: stats.Stage}]
+			switch {
+			case !ok:
+				fmt.Fprint(table, "-\t-\tNEW\t")
+			case isRegression(stats, previous, threshold):
+				regressions++
+				fmt.Fprintf(table, "%s\t%+.1f%%\tREGRESSED\t", round(previous.Median), percentChange(stats.Median, previous.Median))
+			default:
+				fmt.Fprintf(table, "%s\t%+.1f%%\tOK\t", round(previous.Median), percentChange(stats.Median, previous.Median))
+			}
+		}
+
+		fmt.Fprintln(table)
+	}
+
+	table.Flush()
+
+	return regressions
+}
+
+func percentChange(current, previous time.Duration) float64 {
+	if previous == 0 {
+		return 0
+	}
+
+	return float64(current-previous) / float64(previous) * 100
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(time.Microsecond)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+func TestSummarise(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		durations []time.Duration
+
+		// outputs
+		expected benchStats
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		if diff := cmp.Diff(cfg.expected, summarise(cfg.durations)); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+	}
+
+	tests := map[string]Test{
+		"ok: single run": {
+			durations: []time.Duration{5},
+			expected:  benchStats{Runs: 1, Min: 5, Median: 5, P95: 5},
+		},
+
+		"ok: odd number of runs, unsorted": {
+			durations: []time.Duration{30, 10, 20},
+			expected:  benchStats{Runs: 3, Min: 10, Median: 20, P95: 30},
+		},
+
+		"ok: even number of runs": {
+			durations: []time.Duration{40, 10, 30, 20},
+			expected:  benchStats{Runs: 4, Min: 10, Median: 25, P95: 40},
+		},
+
+		"ok: p95 excludes slowest 5%": {
+			durations: []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 100},
+			expected:  benchStats{Runs: 20, Min: 1, Median: 10, P95: 19},
+		},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+// failingSolution parses any input into parts that panic, fail & aren't implemented.
+type failingSolution struct{}
+
+func (s *failingSolution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return solutions.Part{Answer: 1} },
+		func(context.Context) solutions.Part { panic("boom") },
+		func(context.Context) solutions.Part { return solutions.Part{Err: errors.New("no answer")} },
+		func(context.Context) solutions.Part { return solutions.Part{Err: solutions.ErrNotImplemented} },
+	}, nil
+}
+
+func TestBenchDay(t *testing.T) {
+	t.Parallel()
+
+	allStats, err := benchDay(context.Background(), &failingSolution{}, nil, 2)
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	var got []string
+	for _, stats := range allStats {
+		got = append(got, fmt.Sprintf("%s: %s", stats.Stage, stats.Error))
+	}
+
+	expected := []string{"parse: ", "part1: ", "part2: solution panicked (boom)", "part3: no answer"}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Unexpected diff:\n%v", diff)
+	}
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/cache"
+	"github.com/segwin/adventofcode-2020/internal/gomod"
+	"github.com/segwin/adventofcode-2020/internal/history"
+	"github.com/segwin/adventofcode-2020/internal/logging"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+	"github.com/spf13/cobra"
+)
+
+func newCacheCommand() *cobra.Command {
+	var dir string
+
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the result cache",
+		Long: `Manage the result cache, which lets the all command skip the days whose input file &
+solution code haven't changed since they were last solved, reusing their answers instead.
+A solution's code covers its package & the packages it imports from this module, and is
+read from the module's sources when running from within it. Elsewhere, the executable
+itself is used, so any rebuild invalidates every entry.`,
+		Args: cobra.NoArgs,
+	}
+
+	cacheCmd.PersistentFlags().StringVar(&dir, "dir", cache.DefaultDir, "Path to the cache directory")
+
+	cacheCmd.AddCommand(&cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached results",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			store := &cache.Store{Dir: dir}
+
+			removed, err := store.Clear()
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %d cached results from %s\n", removed, dir)
+			return nil
+		},
+	})
+
+	cacheCmd.AddCommand(&cobra.Command{
+		Use:   "stats",
+		Short: "Print statistics about the cached results",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			store := &cache.Store{Dir: dir}
+
+			stats, err := store.Stats()
+			if err != nil {
+				return err
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintf(w, "Directory: %s\nEntries:   %d (%d bytes)\n", dir, stats.Entries, stats.Bytes)
+			if stats.Entries == 0 {
+				return nil
+			}
+
+			fmt.Fprintf(w, "Oldest:    %s\nNewest:    %s\n", stats.Oldest.Local().Format("2006-01-02 15:04:05"), stats.Newest.Local().Format("2006-01-02 15:04:05"))
+
+			years := make([]int, 0, len(stats.Days))
+			for year := range stats.Days {
+				years = append(years, year)
+			}
+
+			sort.Ints(years)
+			for _, year := range years {
+				days := stats.Days[year]
+				sort.Ints(days)
+
+				dayStrs := make([]string, len(days))
+				for i, day := range days {
+					dayStrs[i] = strconv.Itoa(day)
+				}
+
+				fmt.Fprintf(w, "Days %d: %s\n", year, strings.Join(dayStrs, ", "))
+			}
+
+			return nil
+		},
+	})
+
+	return cacheCmd
+}
+
+// dayCache reads & writes the answers of the days solved by the all command from & to a
+// cache.Store. A nil *dayCache caches nothing.
+type dayCache struct {
+	cache   *cache.Store
+	root    string    // module root, empty if the solutions' sources aren't available
+	builtAt time.Time // modification time of the running executable
+
+	once       sync.Once
+	exeVersion string
+	exeErr     error
+}
+
+// newDayCache returns a dayCache using the cache in dir.
+func newDayCache(dir string) *dayCache {
+	c := &dayCache{cache: &cache.Store{Dir: dir}}
+
+	if root, err := gomod.Root(); err == nil {
+		c.root = root
+	}
+
+	if path, err := os.Executable(); err == nil {
+		if info, err := os.Stat(path); err == nil {
+			c.builtAt = info.ModTime()
+		}
+	}
+
+	return c
+}
+
+// lookup returns the key of the given day's answers when solved with the input file at
+// path, along with the outcome cached under that key, if any. An empty key is returned if
+// the answers can't be cached, e.g. if the running executable is older than the
+// solution's sources & may not have been built from them. Errors are only logged, as the
+// day can always be solved instead.
+func (c *dayCache) lookup(ctx context.Context, entry solutions.Entry, path string) (key string, o dayOutcome, ok bool) {
+	if c == nil {
+		return "", dayOutcome{}, false
+	}
+
+	logger := logging.FromContext(ctx)
+
+	inputHash, err := history.HashFile(path)
+	if err != nil {
+		return "", dayOutcome{}, false // reported when solving the day
+	}
+
+	version, err := c.version(entry)
+	if err != nil {
+		logger.Warnf("Not caching results: %v", err)
+		return "", dayOutcome{}, false
+	} else if version == "" {
+		logger.Debugf("Not caching results: sources changed since the executable was built")
+		return "", dayOutcome{}, false
+	}
+
+	key = cache.Key(entry.Year, entry.Day, inputHash, version)
+
+	cached, ok, err := c.cache.Get(key)
+	if err != nil {
+		logger.Warnf("Ignoring cached results: %v", err)
+		return key, dayOutcome{}, false
+	} else if !ok {
+		return key, dayOutcome{}, false
+	}
+
+	o = dayOutcome{
+		path:     path,
+		duration: time.Duration(cached.DurationMS * float64(time.Millisecond)),
+		cached:   true,
+	}
+
+	for _, part := range cached.Parts {
+		o.result.Parts = append(o.result.Parts, solutions.Part{
+			Number:   part.Number,
+			Answer:   part.Answer,
+			Label:    part.Label,
+			Duration: time.Duration(part.DurationMS * float64(time.Millisecond)),
+		})
+	}
+
+	logger.Debugf("Using cached results from %s", cached.Created.Local().Format("2006-01-02 15:04:05"))
+	return key, o, true
+}
+
+// store caches the given outcome under key (see lookup) if every part was solved.
+func (c *dayCache) store(ctx context.Context, key string, entry solutions.Entry, o dayOutcome) {
+	if c == nil || key == "" || o.err != nil {
+		return
+	}
+
+	cached := cache.Entry{
+		Year:       entry.Year,
+		Day:        entry.Day,
+		Created:    time.Now().UTC(),
+		DurationMS: milliseconds(o.duration),
+	}
+
+	for _, part := range o.result.Parts {
+		if part.Err != nil {
+			return
+		}
+
+		cached.Parts = append(cached.Parts, cache.Part{
+			Number:     part.Number,
+			Answer:     fmt.Sprint(part.Answer),
+			Label:      part.Label,
+			DurationMS: milliseconds(part.Duration),
+		})
+	}
+
+	if err := c.cache.Put(key, cached); err != nil {
+		logging.FromContext(ctx).Warnf("Failed to cache results: %v", err)
+	}
+}
+
+// version returns the version of the given day's code (see cache.SourceVersion), read
+// from its sources if available or from the executable otherwise. An empty version is
+// returned if the sources are newer than the executable.
+func (c *dayCache) version(entry solutions.Entry) (string, error) {
+	if c.root != "" {
+		dir := solutionDir(c.root, entry)
+		if _, err := os.Stat(dir); err == nil {
+			version, modTime, err := cache.SourceVersion(c.root, dir)
+			if err != nil || modTime.After(c.builtAt) {
+				return "", err
+			}
+
+			return version, nil
+		}
+	}
+
+	c.once.Do(func() { c.exeVersion, c.exeErr = cache.ExecutableVersion() })
+	return c.exeVersion, c.exeErr
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// LoadConfig returns the CLI's configuration: the built-in defaults, overridden by the
+// configuration files (see config.Paths) & environment variables.
+func LoadConfig() (config.Config, error) {
+	defaults := config.Config{
+		Year:        defaultYear,
+		Inputs:      "inputs",
+		Output:      textOutput,
+		SessionFile: defaultSessionFile(),
+	}
+
+	cfg, err := config.Load(defaults, config.Paths()...)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	if err := cfg.ApplyEnv(os.Getenv); err != nil {
+		return config.Config{}, err
+	}
+
+	return cfg, nil
+}
+
+func newConfigCommand(cfg config.Config) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the configuration",
+		Long: fmt.Sprintf(`Inspect the configuration, which provides the defaults of the input directory,
+default year, output format, session file & timeout flags, along with per-day input &
+timeout overrides. It is loaded from the following files, in order of increasing
+precedence (missing files are skipped):
+
+  %s
+
+followed by the AOC_YEAR, AOC_INPUTS, AOC_OUTPUT, AOC_SESSION_FILE & AOC_TIMEOUT
+environment variables. Flags always take precedence.`, strings.Join(config.Paths(), "\n  ")),
+		Args: cobra.NoArgs,
+	}
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "Print the effective configuration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			data, err := cfg.Marshal()
+			if err != nil {
+				return err
+			}
+
+			sources := "built-in defaults only"
+			if len(cfg.Sources) > 0 {
+				sources = "built-in defaults, " + strings.Join(cfg.Sources, ", ")
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "# Loaded from: %s\n%s", sources, data)
+			return nil
+		},
+	})
+
+	return configCmd
+}
+
+// dayInputFile returns the path to the given day's input file in inputDir. Unless inputDir
+// was set with a flag (flagSet), the day's configured input file takes precedence.
+func dayInputFile(cfg config.Config, inputDir string, flagSet bool, year, day int) string {
+	if override := cfg.Day(year, day).Input; override != "" && !flagSet {
+		return override
+	}
+
+	return dayFile(inputDir, year, day, "input")
+}
+
+// dayTimeout returns the time allowed to solve the given day. Unless timeout was set with
+// a flag (flagSet), the day's configured timeout takes precedence.
+func dayTimeout(cfg config.Config, timeout time.Duration, flagSet bool, year, day int) time.Duration {
+	if override := cfg.Day(year, day).Timeout; override != 0 && !flagSet {
+		return override
+	}
+
+	return timeout
+}
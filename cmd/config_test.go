@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/config"
+)
+
+func TestDayOverrides(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		day      int
+		inputDir string
+		timeout  time.Duration
+		flagSet  bool
+
+		// outputs
+		expectedInput   string
+		expectedTimeout time.Duration
+	}
+
+	configured := config.Config{
+		Year:    2020,
+		Inputs:  "inputs",
+		Timeout: 30 * time.Second,
+		Days:    map[int]config.Day{15: {Input: "day15.txt", Timeout: 2 * time.Minute}},
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		if got := dayInputFile(configured, cfg.inputDir, cfg.flagSet, 2020, cfg.day); got != cfg.expectedInput {
+			t.Errorf("Got %v, expected %v", got, cfg.expectedInput)
+		}
+
+		if got := dayTimeout(configured, cfg.timeout, cfg.flagSet, 2020, cfg.day); got != cfg.expectedTimeout {
+			t.Errorf("Got %v, expected %v", got, cfg.expectedTimeout)
+		}
+	}
+
+	tests := map[string]Test{
+		"no override": {
+			day:             1,
+			inputDir:        "inputs",
+			timeout:         30 * time.Second,
+			expectedInput:   "inputs/2020/day1/input",
+			expectedTimeout: 30 * time.Second,
+		},
+		"override": {
+			day:             15,
+			inputDir:        "inputs",
+			timeout:         30 * time.Second,
+			expectedInput:   "day15.txt",
+			expectedTimeout: 2 * time.Minute,
+		},
+		"flags set to the configured values": {
+			day:             15,
+			inputDir:        "inputs",
+			timeout:         30 * time.Second,
+			flagSet:         true,
+			expectedInput:   "inputs/2020/day15/input",
+			expectedTimeout: 30 * time.Second,
+		},
+		"flags set to other values": {
+			day:             15,
+			inputDir:        "other",
+			timeout:         time.Second,
+			flagSet:         true,
+			expectedInput:   "other/2020/day15/input",
+			expectedTimeout: time.Second,
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
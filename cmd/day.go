@@ -1,93 +1,194 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/logging"
 	"github.com/segwin/adventofcode-2020/internal/solutions"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day1"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day10"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day11"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day12"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day13"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day14"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day15"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day16"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day17"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day18"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day19"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day2"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day20"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day21"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day22"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day23"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day24"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day25"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day3"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day4"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day5"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day6"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day7"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day8"
-	"github.com/segwin/adventofcode-2020/internal/solutions/day9"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/all" // register all solutions
+	"github.com/segwin/adventofcode-2020/internal/watch"
 	"github.com/spf13/cobra"
 )
 
 var (
-	inputFiles = map[int]*string{}
+	ErrInvalidDay  = errors.New("invalid day")
+	ErrPartsFailed = errors.New("some parts failed")
 )
 
-func newDayCommand(day int, solution solutions.Solution) *cobra.Command {
+func newDayCommand(entry solutions.Entry, cfg config.Config) *cobra.Command {
+	year, day := entry.Year, entry.Day
+
+	var (
+		inputFile string
+		output    string
+		timeout   time.Duration
+		part      int
+		watching  bool
+		interval  time.Duration
+		prof      profileFlags
+	)
+
 	dayCmd := &cobra.Command{
 		Use:   fmt.Sprintf("day%d", day),
-		Short: fmt.Sprintf("Run the solution for day %d", day),
+		Short: fmt.Sprintf("Run the solution for day %d: %s", day, entry.Metadata.Title),
 		Args:  cobra.NoArgs,
-		Run: func(cmd *cobra.Command, _ []string) {
-			solution.Run(cmd.Context(), *inputFiles[day])
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var parts []int
+			if part != 0 {
+				parts = append(parts, part)
+			}
+
+			if watching {
+				if prof.Enabled() {
+					return fmt.Errorf("%w (profiling isn't supported in watch mode)", ErrInvalidFlag)
+				}
+
+				if interval <= 0 {
+					return fmt.Errorf("%w (--watch-interval must be positive, got %v)", ErrInvalidFlag, interval)
+				}
+
+				return watchDay(cmd.Context(), cmd.OutOrStdout(), entry, inputFile, timeout, interval, parts)
+			}
+
+			logger := logging.FromContext(cmd.Context()).With(strconv.Itoa(year))
+			writer, err := newResultWriter(output, cmd.OutOrStdout(), logger, false)
+			if err != nil {
+				return err
+			}
+
+			ctx, stopProfile, err := prof.start(dayContext(cmd.Context(), year, day), "")
+			if err != nil {
+				return err
+			}
+
+			result, duration, solveErr := solveFile(ctx, entry.Solution, inputFile, timeout, parts...)
+			if err := stopProfile(); err != nil {
+				return err
+			}
+
+			if err := recordHistory(cmd, year, day, inputFile, result, duration, solveErr); err != nil {
+				return err
+			}
+
+			if err := writer.Write(day, dayOutcome{path: inputFile, result: result, duration: duration, err: solveErr}); err != nil {
+				return err
+			}
+
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+
+			if solveErr != nil {
+				return solveErr
+			}
+
+			return checkParts(result)
 		},
 	}
 
-	inputFiles[day] = new(string)
-	dayCmd.Flags().StringVarP(inputFiles[day], "input", "i", fmt.Sprintf("inputs/day%d/input", day), "Path to input file for this solution")
+	dayCmd.Flags().StringVarP(&inputFile, "input", "i", dayInputFile(cfg, cfg.Inputs, false, year, day), "Path to input file for this solution, or - to read from stdin")
+	dayCmd.Flags().StringVarP(&output, "output", "o", cfg.Output, fmt.Sprintf("Output format, one of %v", outputFormats))
+	dayCmd.Flags().DurationVar(&timeout, "timeout", dayTimeout(cfg, cfg.Timeout, false, year, day), "Maximum time allowed to solve the puzzle, or 0 for no limit")
+	dayCmd.Flags().IntVarP(&part, "part", "p", 0, "Only solve the given part (e.g. 1 or 2), or 0 to solve all parts")
+	dayCmd.Flags().BoolVarP(&watching, "watch", "w", false, "Rebuild & solve again whenever the solution's source or input file changes")
+	dayCmd.Flags().DurationVar(&interval, "watch-interval", watch.DefaultInterval, "Time between two checks for changes in watch mode")
+	addProfileFlags(dayCmd, &prof)
 
 	return dayCmd
 }
 
-var (
-	solutionsList = []solutions.Solution{
-		&day1.Solution{},
-		&day2.Solution{},
-		&day3.Solution{},
-		&day4.Solution{},
-		&day5.Solution{},
-		&day6.Solution{},
-		&day7.Solution{},
-		&day8.Solution{},
-		&day9.Solution{},
-		&day10.Solution{},
-		&day11.Solution{},
-		&day12.Solution{},
-		&day13.Solution{},
-		&day14.Solution{},
-		&day15.Solution{},
-		&day16.Solution{},
-		&day17.Solution{},
-		&day18.Solution{},
-		&day19.Solution{},
-		&day20.Solution{},
-		&day21.Solution{},
-		&day22.Solution{},
-		&day23.Solution{},
-		&day24.Solution{},
-		&day25.Solution{},
+// checkParts returns an error wrapping ErrPartsFailed if any part of result failed for a
+// reason other than not being implemented yet.
+func checkParts(result solutions.Result) error {
+	failed := 0
+	for _, part := range result.Parts {
+		if part.Err != nil && !errors.Is(part.Err, solutions.ErrNotImplemented) {
+			failed++
+		}
 	}
-)
 
-func newDayCommands() map[string]*cobra.Command {
+	if failed > 0 {
+		return fmt.Errorf("%w (%d of %d)", ErrPartsFailed, failed, len(result.Parts))
+	}
+
+	return nil
+}
+
+func newDayCommands(year int, cfg config.Config) map[string]*cobra.Command {
 	commands := map[string]*cobra.Command{}
-	for i, solution := range solutionsList {
-		day := i + 1
-		commands[fmt.Sprintf("day%d", day)] = newDayCommand(day, solution)
+	for _, entry := range solutions.List(year) {
+		commands[fmt.Sprintf("day%d", entry.Day)] = newDayCommand(entry, cfg)
 	}
 
 	return commands
 }
+
+// selectEntries returns the registered solutions of the given year for the given day
+// arguments (e.g. "5" or "day5"). If no arguments are given, all registered solutions
+// for that year are returned.
+func selectEntries(year int, args []string) (entries []solutions.Entry, err error) {
+	if len(args) == 0 {
+		return solutions.List(year), nil
+	}
+
+	for _, arg := range args {
+		day, err := strconv.Atoi(strings.TrimPrefix(arg, "day"))
+		if err != nil {
+			return nil, fmt.Errorf("%w (%q)", ErrInvalidDay, arg)
+		}
+
+		entry, ok := solutions.Get(year, day)
+		if !ok {
+			return nil, fmt.Errorf("%w (%q)", ErrInvalidDay, arg)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// dayContext returns a copy of ctx whose logger identifies the given day, so that
+// messages logged while solving it can be told apart from other days'.
+func dayContext(ctx context.Context, year, day int) context.Context {
+	return logging.WithLogger(ctx, logging.FromContext(ctx).With(fmt.Sprintf("%d day %d", year, day)))
+}
+
+// solveFile runs the given solution against the contents of the input file at path
+// (or standard input if path is "-"), returning its result along with the time taken
+// to solve it. Only the given parts are solved, or all parts if none are given. If
+// timeout is non-zero, the solution is interrupted once it has run for
+// that long and an error wrapping solutions.ErrTimeout is returned.
+func solveFile(ctx context.Context, solution solutions.Solution, path string, timeout time.Duration, parts ...int) (result solutions.Result, duration time.Duration, err error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	file, err := input.Open(path)
+	if err != nil {
+		return solutions.Result{}, 0, err
+	}
+
+	defer file.Close()
+
+	start := time.Now()
+	result, err = solutions.Solve(ctx, solution, file, parts...)
+	duration = time.Since(start)
+
+	// a solution may only report the interruption in one of its parts, but its
+	// result can't be trusted either way
+	if ctxErr := solutions.CheckContext(ctx); ctxErr != nil {
+		return solutions.Result{}, duration, ctxErr
+	}
+
+	return result, duration, err
+}
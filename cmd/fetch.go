@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/spf13/cobra"
+)
+
+func newFetchCommand(year int, cfg config.Config) *cobra.Command {
+	var (
+		fetcherCfg  input.FetcherConfig
+		sessionFile string
+	)
+
+	fetchCmd := &cobra.Command{
+		Use:   "fetch [days...]",
+		Short: "Download the puzzle inputs for the given days (or all days if none are given)",
+		Long: `Download the puzzle inputs for the given days (or all days if none are given),
+caching them as <dir>/<year>/day<N>/input. Inputs that are already cached are not
+downloaded again unless --force is given.
+
+The session token is read from the AOC_SESSION environment variable or, if unset,
+from the file given by --session-file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := selectEntries(year, args)
+			if err != nil {
+				return err
+			}
+
+			if fetcherCfg.Session, err = readSession(sessionFile); err != nil {
+				return err
+			}
+
+			fetcher := input.NewFetcher(fetcherCfg)
+			for _, entry := range entries {
+				path, err := fetcher.Fetch(cmd.Context(), year, entry.Day)
+				if err != nil {
+					return fmt.Errorf("day %d: %w", entry.Day, err)
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "Day %d: %s\n", entry.Day, path)
+			}
+
+			return nil
+		},
+	}
+
+	fetchCmd.Flags().StringVarP(&fetcherCfg.CacheDir, "input", "i", cfg.Inputs, "Path to directory to store input files in, structured as <dir>/<year>/day<N>/input")
+	fetchCmd.Flags().BoolVar(&fetcherCfg.Force, "force", false, "Download inputs even if they are already cached")
+	fetchCmd.Flags().StringVar(&fetcherCfg.BaseURL, "base-url", defaultBaseURL(), "Base URL of the puzzle server (default can be set with AOC_BASE_URL)")
+	fetchCmd.Flags().DurationVar(&fetcherCfg.MinInterval, "min-interval", input.DefaultMinInterval, "Minimum time to wait between two requests")
+	fetchCmd.Flags().StringVar(&sessionFile, "session-file", cfg.SessionFile, "Path to file containing the session token, used if AOC_SESSION is unset")
+
+	return fetchCmd
+}
+
+// defaultBaseURL returns the default URL of the puzzle server, taken from the
+// AOC_BASE_URL environment variable if set.
+func defaultBaseURL() string {
+	if baseURL := os.Getenv("AOC_BASE_URL"); baseURL != "" {
+		return baseURL
+	}
+
+	return input.DefaultBaseURL
+}
+
+// defaultSessionFile returns the default path of the session token file.
+func defaultSessionFile() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(configDir, "aoc", "session")
+}
+
+// readSession returns the session token from the AOC_SESSION environment variable or,
+// if unset, from the given file. A missing file results in an empty token.
+func readSession(sessionFile string) (session string, err error) {
+	if session := os.Getenv("AOC_SESSION"); session != "" {
+		return session, nil
+	}
+
+	if sessionFile == "" {
+		return "", nil
+	}
+
+	data, err := ioutil.ReadFile(sessionFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to read session file (%w)", err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/history"
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+	"github.com/spf13/cobra"
+)
+
+// Names of the root command's persistent flags controlling the run history.
+const (
+	recordFlag      = "record"
+	historyFileFlag = "history-file"
+)
+
+func newHistoryCommand(year int) *cobra.Command {
+	var (
+		part  int
+		limit int
+	)
+
+	historyCmd := &cobra.Command{
+		Use:   "history <day>",
+		Short: "Show how a day's answers & runtimes changed over recorded runs",
+		Long: `Show the runs of the given day recorded with --record, oldest first. Runs whose
+answer differs from the previous run on the same input are flagged: this usually means
+a refactor changed the solution's behaviour.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := selectEntries(year, args)
+			if err != nil {
+				return err
+			}
+
+			day := entries[0].Day
+
+			store := &history.Store{Path: historyFile(cmd)}
+			records, err := store.Load(year, day)
+			if err != nil {
+				return err
+			}
+
+			changes := history.Changes(records)
+
+			// only keep the requested part & the latest runs, now that they were compared
+			var shown []int
+			for i, r := range records {
+				if part == 0 || r.Part == part {
+					shown = append(shown, i)
+				}
+			}
+
+			if limit > 0 && len(shown) > limit {
+				shown = shown[len(shown)-limit:]
+			}
+
+			if len(shown) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No recorded runs for %d day %d in %s\n", year, day, store.Path)
+				return nil
+			}
+
+			table := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(table, "TIME\tCOMMIT\tPART\tANSWER\tDURATION\tINPUT\tCHANGE")
+
+			for _, i := range shown {
+				r := records[i]
+
+				answer := r.Answer
+				if r.Error != "" {
+					answer = "ERROR: " + r.Error
+				}
+
+				partStr := strconv.Itoa(r.Part)
+				if r.Part == 0 {
+					partStr = "-"
+				}
+
+				change := string(changes[i])
+				if changes[i] == history.AnswerChanged {
+					change = strings.ToUpper(change) // make it stand out
+				}
+
+				duration := time.Duration(r.DurationMS * float64(time.Millisecond)).Round(time.Microsecond)
+				fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.Time.Local().Format("2006-01-02 15:04:05"), orDash(r.Commit), partStr, answer, duration, orDash(shortHash(r.InputHash)), change)
+			}
+
+			return table.Flush()
+		},
+	}
+
+	historyCmd.Flags().IntVarP(&part, "part", "p", 0, "Only show the given part, or 0 to show all parts")
+	historyCmd.Flags().IntVarP(&limit, "limit", "n", 0, "Only show the latest N records, or 0 to show all of them")
+
+	return historyCmd
+}
+
+// recordHistory appends the outcome of solving a day to the run history, if recording was
+// requested with the --record flag.
+func recordHistory(cmd *cobra.Command, year, day int, inputPath string, result solutions.Result, duration time.Duration, err error) error {
+	if record, _ := cmd.Flags().GetBool(recordFlag); !record {
+		return nil
+	}
+
+	var inputHash string
+	if inputPath != input.Stdin {
+		// an unreadable input was already reported as the day's error
+		inputHash, _ = history.HashFile(inputPath)
+	}
+
+	now, commit := time.Now().UTC(), history.GitCommit(cmd.Context())
+
+	var records []history.Record
+	for _, r := range toRecords(day, result, duration, err) {
+		records = append(records, history.Record{
+			Time:       now,
+			Commit:     commit,
+			Year:       year,
+			Day:        r.Day,
+			Part:       r.Part,
+			Answer:     r.Answer,
+			Error:      r.Error,
+			DurationMS: r.DurationMS,
+			InputHash:  inputHash,
+		})
+	}
+
+	store := &history.Store{Path: historyFile(cmd)}
+	return store.Append(records...)
+}
+
+// historyFile returns the path to the history file given by the --history-file flag.
+func historyFile(cmd *cobra.Command) string {
+	if path, _ := cmd.Flags().GetString(historyFileFlag); path != "" {
+		return path
+	}
+
+	return history.DefaultPath
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+
+	return hash
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/segwin/adventofcode-2020/internal/lint"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ErrLintFailed = errors.New("input validation failed")
+)
+
+func newLintCommand(year int, cfg config.Config) *cobra.Command {
+	var inputDir string
+
+	lintCmd := &cobra.Command{
+		Use:   "lint-inputs [days...]",
+		Short: "Validate the input files of the given days (or all days) without solving them",
+		Long: `Check the input file & any example files of the given days (or all days if none
+are given) in <dir>/<year>/day<N>, reporting files that are missing, empty, CRLF-terminated,
+have trailing whitespace or can't be parsed by the day's solution. Only the parser runs:
+no part is solved.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := selectEntries(year, args)
+			if err != nil {
+				return err
+			}
+
+			var issues int
+			for _, entry := range entries {
+				examples, err := filepath.Glob(dayFile(inputDir, year, entry.Day, "example*"))
+				if err != nil {
+					return err
+				}
+
+				paths := []string{dayInputFile(cfg, inputDir, cmd.Flags().Changed("input"), year, entry.Day)}
+				for _, example := range examples {
+					if !strings.HasSuffix(example, ".expected") {
+						paths = append(paths, example)
+					}
+				}
+
+				for _, path := range paths {
+					found, err := lint.File(cmd.Context(), entry.Solution, path)
+					if err != nil {
+						return fmt.Errorf("day %d: %w", entry.Day, err)
+					}
+
+					for _, issue := range found {
+						fmt.Fprintln(cmd.OutOrStdout(), issue)
+					}
+
+					issues += len(found)
+				}
+			}
+
+			if issues > 0 {
+				return fmt.Errorf("%w (%d issues)", ErrLintFailed, issues)
+			}
+
+			return nil
+		},
+	}
+
+	lintCmd.Flags().StringVarP(&inputDir, "input", "i", cfg.Inputs, "Path to directory containing all input files, structured as <dir>/<year>/day<N>/input")
+
+	return lintCmd
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+	"github.com/spf13/cobra"
+)
+
+func newListCommand(year int) *cobra.Command {
+	var tag string
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all available solutions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			table := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(table, "DAY\tTITLE\tRUNTIME\tTAGS")
+
+			for _, entry := range solutions.List(year) {
+				if tag != "" && !entry.Metadata.HasTag(tag) {
+					continue
+				}
+
+				fmt.Fprintf(table, "%d\t%s\t%s\t%s\n", entry.Day, entry.Metadata.Title, entry.Metadata.Runtime, strings.Join(entry.Metadata.Tags, ", "))
+			}
+
+			return table.Flush()
+		},
+	}
+
+	listCmd.Flags().StringVarP(&tag, "tag", "t", "", "Only list solutions with the given tag")
+
+	return listCmd
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+func newNewCommand(year int) *cobra.Command {
+	cfg := scaffold.Config{Root: "."}
+
+	newCmd := &cobra.Command{
+		Use:   "new <day>",
+		Short: "Create the package for a new day from a template",
+		Long: `Create the package for the given day (e.g. "17" or "day17") from a template, along
+with a test skeleton & empty input/example files, and register it with the CLI. Packages that are
+still stubs are replaced, but existing solutions are never overwritten.
+
+The new solution is available once the app has been rebuilt.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			day, err := strconv.Atoi(strings.TrimPrefix(args[0], "day"))
+			if err != nil {
+				return fmt.Errorf("%w (%q)", ErrInvalidDay, args[0])
+			}
+
+			cfg.Day = day
+
+			written, err := scaffold.Generate(cfg)
+			if err != nil {
+				return err
+			}
+
+			for _, path := range written {
+				fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", path)
+			}
+
+			return nil
+		},
+	}
+
+	newCmd.Flags().IntVar(&cfg.Year, "year", year, "Event year of the new day")
+	newCmd.Flags().StringVarP(&cfg.Title, "title", "t", "", "Title of the puzzle (defaults to \"Day <N>\")")
+	newCmd.Flags().StringVar(&cfg.Root, "root", cfg.Root, "Path to the root of the module")
+
+	return newCmd
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/logging"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+var (
+	ErrUnknownOutputFormat = errors.New("unknown output format")
+)
+
+const (
+	textOutput   = "text"
+	jsonOutput   = "json"
+	ndjsonOutput = "ndjson"
+	csvOutput    = "csv"
+)
+
+var (
+	outputFormats = []string{textOutput, jsonOutput, ndjsonOutput, csvOutput}
+)
+
+// resultWriter renders solution results in a given output format.
+type resultWriter interface {
+	// Write renders the outcome of solving the given day. If its err is set, the day
+	// could not be solved at all and its result is ignored. Outcomes read from the cache
+	// are marked as such, except in CSV output so that its columns stay the same.
+	Write(day int, o dayOutcome) error
+
+	// Flush writes any buffered output. It must be called once all results have been
+	// written.
+	Flush() error
+}
+
+// newResultWriter returns a resultWriter for the given output format. If headers is
+// true, text output is preceded by a header identifying each day. Text output reports
+// the parts that couldn't be solved to logger rather than w, while other formats include
+// them in their records.
+func newResultWriter(format string, w io.Writer, logger *logging.Logger, headers bool) (resultWriter, error) {
+	switch format {
+	case textOutput:
+		return &textWriter{w: w, logger: logger, headers: headers}, nil
+	case jsonOutput:
+		return &jsonWriter{w: w, records: []record{}}, nil
+	case ndjsonOutput:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case csvOutput:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	}
+
+	return nil, fmt.Errorf("%w (%q, expected one of %v)", ErrUnknownOutputFormat, format, outputFormats)
+}
+
+// record is a single machine-readable output entry, one per day & part. Part is 0 if
+// the day as a whole failed. DurationMS is the time taken to solve the part, or the whole
+// day if it failed, and Cached is true if the answer was read from the cache rather than
+// solved again.
+type record struct {
+	Day        int     `json:"day"`
+	Part       int     `json:"part"`
+	Answer     string  `json:"answer,omitempty"`
+	DurationMS float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
+	Cached     bool    `json:"cached,omitempty"`
+}
+
+func toRecords(day int, result solutions.Result, duration time.Duration, err error) (records []record) {
+	if err != nil {
+		return []record{{Day: day, DurationMS: milliseconds(duration), Error: err.Error()}}
+	}
+
+	for _, part := range result.Parts {
+		r := record{Day: day, Part: part.Number, DurationMS: milliseconds(part.Duration)}
+		if part.Err != nil {
+			r.Error = part.Err.Error()
+		} else {
+			r.Answer = fmt.Sprint(part.Answer)
+		}
+
+		records = append(records, r)
+	}
+
+	return records
+}
+
+// milliseconds returns d as a fractional number of milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// outcomeRecords returns the records of the given day's outcome.
+func outcomeRecords(day int, o dayOutcome) []record {
+	records := toRecords(day, o.result, o.duration, o.err)
+	for i := range records {
+		records[i].Cached = o.cached
+	}
+
+	return records
+}
+
+type textWriter struct {
+	w       io.Writer
+	logger  *logging.Logger
+	headers bool
+}
+
+func (t *textWriter) Write(day int, o dayOutcome) error {
+	if t.headers {
+		divider := "----------"
+		if day >= 10 {
+			divider += "-"
+		}
+
+		fmt.Fprintf(t.w, "%s\n  Day %d\n%s\n", divider, day, divider)
+	}
+
+	if o.err != nil {
+		return nil // day-level errors are reported by the caller
+	}
+
+	printResult(t.w, t.logger.With(fmt.Sprintf("day %d", day)), o.result)
+	if t.headers {
+		fmt.Fprintf(t.w, "\nTIME: %s%s\n\n", o.duration.Round(time.Microsecond), cachedMarker(o.cached))
+	}
+
+	return nil
+}
+
+func (t *textWriter) Flush() error { return nil }
+
+type jsonWriter struct {
+	w       io.Writer
+	records []record
+}
+
+func (j *jsonWriter) Write(day int, o dayOutcome) error {
+	j.records = append(j.records, outcomeRecords(day, o)...)
+	return nil
+}
+
+func (j *jsonWriter) Flush() error {
+	encoder := json.NewEncoder(j.w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(j.records)
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(day int, o dayOutcome) error {
+	for _, r := range outcomeRecords(day, o) {
+		if err := n.encoder.Encode(r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *ndjsonWriter) Flush() error { return nil }
+
+type csvWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.wroteHeader {
+		return nil
+	}
+
+	c.wroteHeader = true
+	return c.w.Write([]string{"day", "part", "answer", "duration_ms", "error"})
+}
+
+func (c *csvWriter) Write(day int, o dayOutcome) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	for _, r := range toRecords(day, o.result, o.duration, o.err) {
+		row := []string{
+			strconv.Itoa(r.Day),
+			strconv.Itoa(r.Part),
+			r.Answer,
+			strconv.FormatFloat(r.DurationMS, 'f', 3, 64),
+			r.Error,
+		}
+
+		if err := c.w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *csvWriter) Flush() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	c.w.Flush()
+	return c.w.Error()
+}
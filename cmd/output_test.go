@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segwin/adventofcode-2020/internal/logging"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+func TestResultWriter(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		format   string
+		result   solutions.Result
+		duration time.Duration
+		err      error
+		cached   bool
+
+		// outputs
+		expected    string
+		expectedLog string
+		expectedErr error
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		var buf, log bytes.Buffer
+		writer, err := newResultWriter(cfg.format, &buf, logging.New(&log, logging.Info), false)
+		if !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+
+		if err != nil {
+			return // we're done
+		}
+
+		if err := writer.Write(5, dayOutcome{result: cfg.result, duration: cfg.duration, err: cfg.err, cached: cfg.cached}); err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		if err := writer.Flush(); err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		if diff := cmp.Diff(cfg.expected, buf.String()); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+
+		if diff := cmp.Diff(cfg.expectedLog, log.String()); diff != "" {
+			t.Errorf("Unexpected log diff:\n%v", diff)
+		}
+	}
+
+	result := solutions.Result{
+		Parts: []solutions.Part{
+			{Number: 1, Answer: 953, Label: "Highest seat ID", Duration: 1500 * time.Microsecond},
+			{Number: 2, Err: solutions.ErrNotImplemented, Duration: 2 * time.Millisecond},
+		},
+	}
+
+	tests := map[string]Test{
+		"error: unknown format": {
+			format:      "xml",
+			expectedErr: ErrUnknownOutputFormat,
+		},
+
+		"ok: text": {
+			format:      textOutput,
+			result:      result,
+			expected:    "\nPART 1\n  RESULT: Highest seat ID = 953\n",
+			expectedLog: "ERROR [day 5] Part 2: not implemented\n",
+		},
+
+		"ok: ndjson": {
+			format:   ndjsonOutput,
+			result:   result,
+			duration: 1500 * time.Microsecond,
+			expected: `{"day":5,"part":1,"answer":"953","duration_ms":1.5}` + "\n" +
+				`{"day":5,"part":2,"duration_ms":2,"error":"not implemented"}` + "\n",
+		},
+
+		"ok: ndjson, big answer": {
+			format:   ndjsonOutput,
+			result:   solutions.Result{Parts: []solutions.Part{{Number: 1, Answer: big.NewInt(56693912375296)}}},
+			expected: `{"day":5,"part":1,"answer":"56693912375296","duration_ms":0}` + "\n",
+		},
+
+		"ok: ndjson, cached": {
+			format:   ndjsonOutput,
+			result:   solutions.Result{Parts: []solutions.Part{{Number: 1, Answer: 953, Duration: time.Millisecond}}},
+			duration: 2 * time.Millisecond,
+			cached:   true,
+			expected: `{"day":5,"part":1,"answer":"953","duration_ms":1,"cached":true}` + "\n",
+		},
+
+		"ok: csv": {
+			format:   csvOutput,
+			result:   result,
+			duration: 2 * time.Millisecond,
+			expected: "day,part,answer,duration_ms,error\n5,1,953,1.500,\n5,2,,2.000,not implemented\n",
+		},
+
+		"ok: csv, day failed": {
+			format:   csvOutput,
+			err:      errors.New("bad input"),
+			expected: "day,part,answer,duration_ms,error\n5,0,,0.000,bad input\n",
+		},
+
+		"ok: json, day failed": {
+			format:   jsonOutput,
+			err:      errors.New("bad input"),
+			expected: "[\n  {\n    \"day\": 5,\n    \"part\": 0,\n    \"duration_ms\": 0,\n    \"error\": \"bad input\"\n  }\n]\n",
+		},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
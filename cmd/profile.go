@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segwin/adventofcode-2020/internal/profile"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+	"github.com/spf13/cobra"
+)
+
+// profileFlags holds the profiling settings shared by the commands solving days.
+type profileFlags struct {
+	profile.Config
+	perPart bool
+}
+
+// addProfileFlags adds the flags setting p to cmd.
+func addProfileFlags(cmd *cobra.Command, p *profileFlags) {
+	cmd.Flags().StringVar(&p.CPU, "cpuprofile", "", "Write a CPU profile to the given file, for use with 'go tool pprof'")
+	cmd.Flags().StringVar(&p.Mem, "memprofile", "", "Write a heap profile to the given file once solved, for use with 'go tool pprof'")
+	cmd.Flags().StringVar(&p.Trace, "trace", "", "Write an execution trace to the given file, for use with 'go tool trace'")
+	cmd.Flags().BoolVar(&p.perPart, "profile-parts", false, "Write separate profiles for parsing & each part, named e.g. cpu.part1.pprof")
+}
+
+// start starts profiling the code run with the returned context, adding label to the
+// profiles' file names (see profile.Config.Start). The returned function stops profiling
+// & writes the profiles. When profiling each part separately, profiles are only captured
+// around the stages of solutions.Solve.
+func (p profileFlags) start(ctx context.Context, label string) (context.Context, func() error, error) {
+	if !p.Enabled() {
+		return ctx, func() error { return nil }, nil
+	}
+
+	if !p.perPart {
+		session, err := p.Start(label)
+		if err != nil {
+			return ctx, nil, err
+		}
+
+		return ctx, session.Stop, nil
+	}
+
+	var firstErr error
+	hook := func(stage string) func() {
+		if label != "" {
+			stage = fmt.Sprintf("%s.%s", label, stage)
+		}
+
+		session, err := p.Start(stage)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			return func() {}
+		}
+
+		return func() {
+			if err := session.Stop(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return solutions.WithStageHook(ctx, hook), func() error { return firstErr }, nil
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/segwin/adventofcode-2020/internal/logging"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+// printResult writes a human-readable rendering of the answers in the given result to
+// w. Parts that couldn't be solved are reported to logger instead.
+func printResult(w io.Writer, logger *logging.Logger, result solutions.Result) {
+	for _, part := range result.Parts {
+		if part.Err != nil {
+			logger.Errorf("Part %d: %v", part.Number, part.Err)
+			continue
+		}
+
+		fmt.Fprintf(w, "\nPART %d\n  RESULT: %s = %v\n", part.Number, part.Label, part.Answer)
+	}
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/segwin/adventofcode-2020/internal/report"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+	"github.com/spf13/cobra"
+)
+
+func newReportCommand(year int, cfg config.Config) *cobra.Command {
+	var (
+		inputDir string
+		format   string
+		out      string
+		linkBase string
+		jobs     int
+		timeout  time.Duration
+	)
+
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Solve every day & write a report of the answers, timings & statuses",
+		Long: `Solve every day, then write a Markdown or HTML report with a table of each part's
+answer, time & status. Answers are compared to those recorded in
+<dir>/<year>/day<N>/answers (see verify), and each day links to its solution package
+so the Markdown report can be committed into the README.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if jobs < 1 {
+				return fmt.Errorf("%w (--jobs must be at least 1, got %d)", ErrInvalidFlag, jobs)
+			}
+
+			// don't solve everything only to fail on a typo
+			if err := report.CheckFormat(format); err != nil {
+				return err
+			}
+
+			opts := solveOptions{
+				cfg:        cfg,
+				inputDir:   inputDir,
+				timeout:    timeout,
+				inputSet:   cmd.Flags().Changed("input"),
+				timeoutSet: cmd.Flags().Changed("timeout"),
+			}
+
+			r, err := buildReport(cmd.Context(), year, linkBase, jobs, opts)
+			if err != nil {
+				return err
+			}
+
+			if out == "" {
+				return report.Render(cmd.OutOrStdout(), format, r)
+			}
+
+			file, err := os.Create(out)
+			if err != nil {
+				return fmt.Errorf("failed to create report (%w)", err)
+			}
+
+			if err := report.Render(file, format, r); err != nil {
+				file.Close()
+				return err
+			}
+
+			return file.Close()
+		},
+	}
+
+	reportCmd.Flags().StringVarP(&inputDir, "input", "i", cfg.Inputs, "Path to directory containing all input & answer files, structured as <dir>/<year>/day<N>/{input,answers}")
+	reportCmd.Flags().StringVarP(&format, "format", "f", report.Markdown, fmt.Sprintf("Report format, one of %v", report.Formats))
+	reportCmd.Flags().StringVarP(&out, "out", "O", "", "Path to write the report to, instead of standard output")
+	reportCmd.Flags().StringVar(&linkBase, "link-base", "internal/solutions", "Path or URL that links to solution packages are relative to, as <base>/<year>/day<N>")
+	reportCmd.Flags().IntVarP(&jobs, "jobs", "j", 1, "Number of days to solve concurrently")
+	reportCmd.Flags().DurationVar(&timeout, "timeout", cfg.Timeout, "Maximum time allowed to solve each day, or 0 for no limit")
+
+	return reportCmd
+}
+
+// buildReport solves every day of the given year & gathers the outcomes in a report.
+func buildReport(ctx context.Context, year int, linkBase string, jobs int, opts solveOptions) (report.Report, error) {
+	r := report.Report{Year: year, Generated: time.Now()}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	entries := solutions.List(year)
+	outcomes := solveAll(ctx, entries, jobs, opts)
+
+	for i, outcome := range outcomes {
+		entry := entries[i]
+
+		expected, err := readAnswers(ctx, dayFile(opts.inputDir, year, entry.Day, "answers"))
+		if err != nil {
+			return report.Report{}, fmt.Errorf("day %d: %w", entry.Day, err)
+		}
+
+		o := <-outcome
+		day := report.Day{
+			Day:      entry.Day,
+			Title:    entry.Metadata.Title,
+			Link:     fmt.Sprintf("%s/%d/day%d", strings.TrimSuffix(linkBase, "/"), year, entry.Day),
+			Duration: o.duration,
+		}
+
+		if o.err != nil {
+			day.Err = o.err.Error()
+		}
+
+		for _, part := range o.result.Parts {
+			p := report.Part{Number: part.Number, Duration: part.Duration}
+			if part.Number <= len(expected) {
+				p.Expected = expected[part.Number-1]
+			}
+
+			if part.Err != nil {
+				p.Err = part.Err.Error()
+				p.Skipped = errors.Is(part.Err, solutions.ErrNotImplemented)
+			} else {
+				p.Answer = fmt.Sprint(part.Answer)
+			}
+
+			day.Parts = append(day.Parts, p)
+		}
+
+		r.Days = append(r.Days, day)
+	}
+
+	return r, nil
+}
@@ -1,20 +1,65 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/segwin/adventofcode-2020/internal/history"
+	"github.com/segwin/adventofcode-2020/internal/logging"
 	"github.com/spf13/cobra"
 )
 
-func New(name string) *cobra.Command {
+var (
+	ErrInvalidFlag = errors.New("invalid flag value")
+)
+
+// New returns the app's root command, using cfg (see LoadConfig) for the defaults of its
+// flags. Messages are logged through the logger carried by the context it is executed
+// with (see logging.WithLogger), at the level selected by the --verbose & --quiet flags.
+func New(name string, cfg config.Config) *cobra.Command {
+	var verbose, quiet bool
+
 	rootCmd := &cobra.Command{
 		Use:   name,
-		Short: "Collection of solutions for the Advent of Code 2020 event",
+		Short: "Collection of solutions for the Advent of Code events",
+
+		// errors are reported by the caller, no need to print them twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			logger := logging.FromContext(cmd.Context())
+			switch {
+			case verbose && quiet:
+				return fmt.Errorf("%w (--verbose & --quiet are mutually exclusive)", ErrInvalidFlag)
+			case verbose:
+				logger.SetLevel(logging.Debug)
+			case quiet:
+				logger.SetLevel(logging.Error)
+			}
+
+			return nil
+		},
 	}
 
-	for _, cmd := range newDayCommands() {
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Log debug traces from the solutions to standard error")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Only log errors to standard error")
+
+	rootCmd.PersistentFlags().Bool(recordFlag, false, "Append the answers & timings of solved days to the run history")
+	rootCmd.PersistentFlags().String(historyFileFlag, history.DefaultPath, "Path to the run history file")
+
+	for _, cmd := range newYearCommands(cfg) {
 		rootCmd.AddCommand(cmd)
 	}
 
-	rootCmd.AddCommand(newAllCommand())
+	// keep the default year's commands available without a year prefix
+	addYearCommands(rootCmd, cfg.Year, cfg)
+
+	rootCmd.AddCommand(newNewCommand(cfg.Year))
+	rootCmd.AddCommand(newConfigCommand(cfg))
+	rootCmd.AddCommand(newCacheCommand())
+	rootCmd.AddCommand(newServeCommand())
 
 	return rootCmd
 }
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/server"
+	"github.com/spf13/cobra"
+)
+
+// shutdownTimeout is the time given to in-flight requests to complete once the server
+// is asked to stop.
+const shutdownTimeout = 5 * time.Second
+
+func newServeCommand() *cobra.Command {
+	var (
+		addr string
+		cfg  server.Config
+	)
+
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the solutions over an HTTP API",
+		Long: `Serve the registered solutions of all years over an HTTP API:
+
+  GET  /healthz                       liveness check
+  GET  /metrics                       request & solve counters, in Prometheus text format
+  GET  /v1/days[?year=<year>]         registered solutions
+  POST /v1/<year>/day/<n>[?part=<p>]  solve a puzzle with the request body as input
+
+The server stops gracefully on SIGINT or SIGTERM.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// bind before announcing the address, so that e.g. a port already in use is
+			// reported as such
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen (%w)", err)
+			}
+
+			srv := &http.Server{Handler: server.New(cfg)}
+
+			errs := make(chan error, 1)
+			go func() { errs <- srv.Serve(ln) }()
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Listening on %s\n", ln.Addr())
+
+			// the command's context is canceled on SIGINT or SIGTERM
+			select {
+			case err := <-errs:
+				return err
+			case <-cmd.Context().Done():
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+
+			if err := srv.Shutdown(ctx); err != nil {
+				return fmt.Errorf("failed to shut down server (%w)", err)
+			}
+
+			if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	serveCmd.Flags().StringVar(&addr, "addr", ":8080", "Address to listen on")
+	serveCmd.Flags().DurationVar(&cfg.Timeout, "timeout", server.DefaultTimeout, "Maximum time allowed to solve a puzzle in a single request")
+	serveCmd.Flags().Int64Var(&cfg.MaxInputSize, "max-input-size", server.DefaultMaxInputSize, "Maximum size of a puzzle input, in bytes")
+
+	return serveCmd
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/segwin/adventofcode-2020/internal/submit"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ErrWrongAnswer    = errors.New("wrong answer")
+	ErrUnknownVerdict = errors.New("unknown verdict")
+)
+
+func newSubmitCommand(year int, cfg config.Config) *cobra.Command {
+	var (
+		submitCfg   submit.Config
+		sessionFile string
+		logFile     string
+		inputFile   string
+		part        int
+		timeout     time.Duration
+	)
+
+	submitCmd := &cobra.Command{
+		Use:   "submit <day>",
+		Short: "Solve a part of a day & submit its answer",
+		Long: `Solve the given part of a day, then post its answer to the puzzle server & show
+its verdict. Every attempt is recorded in the submission log (see --log-file), and an
+answer isn't submitted if it can't be right given past attempts: the part was already
+solved, the same answer was already judged wrong, or it lies beyond an answer that was
+too high or too low. It also isn't submitted while the server asks to wait.
+
+The session token is read from the AOC_SESSION environment variable or, if unset,
+from the file given by --session-file.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if part < 1 {
+				return fmt.Errorf("%w (--part must be at least 1, got %d)", ErrInvalidFlag, part)
+			}
+
+			entries, err := selectEntries(year, args)
+			if err != nil {
+				return err
+			}
+
+			entry := entries[0]
+			if inputFile == "" {
+				inputFile = dayInputFile(cfg, cfg.Inputs, false, year, entry.Day)
+			}
+
+			ctx := dayContext(cmd.Context(), year, entry.Day)
+			result, _, err := solveFile(ctx, entry.Solution, inputFile, dayTimeout(cfg, timeout, cmd.Flags().Changed("timeout"), year, entry.Day), part)
+			if err != nil {
+				return err
+			} else if err := result.Parts[0].Err; err != nil {
+				return fmt.Errorf("part %d: %w", part, err)
+			}
+
+			answer := fmt.Sprint(result.Parts[0].Answer)
+
+			log := &submit.Log{Path: logFile}
+			attempts, err := log.Load(year, entry.Day)
+			if err != nil {
+				return err
+			}
+
+			if err := submit.Check(attempts, part, answer, time.Now()); err != nil {
+				return fmt.Errorf("not submitting %s: %w", answer, err)
+			}
+
+			if submitCfg.Session, err = readSession(sessionFile); err != nil {
+				return err
+			}
+
+			now := time.Now()
+			outcome, err := submit.New(submitCfg).Submit(cmd.Context(), year, entry.Day, part, answer)
+			if err != nil {
+				return err
+			}
+
+			attempt := submit.Attempt{
+				Time:    now,
+				Year:    year,
+				Day:     entry.Day,
+				Part:    part,
+				Answer:  answer,
+				Verdict: outcome.Verdict,
+				WaitS:   outcome.Wait.Seconds(),
+			}
+
+			if err := log.Append(attempt); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Day %d part %d: %s\n  %s\n", entry.Day, part, answer, outcome.Message)
+
+			switch outcome.Verdict {
+			case submit.Correct:
+				return nil
+			case submit.TooHigh, submit.TooLow, submit.Wrong:
+				return fmt.Errorf("%w (%s)", ErrWrongAnswer, outcome.Verdict)
+			case submit.RateLimited:
+				return fmt.Errorf("%w (wait %s)", submit.ErrMustWait, outcome.Wait)
+			case submit.AlreadySolved:
+				return submit.ErrAlreadySolved
+			}
+
+			return ErrUnknownVerdict
+		},
+	}
+
+	submitCmd.Flags().IntVarP(&part, "part", "p", 0, "Part to solve & submit (e.g. 1 or 2)")
+	submitCmd.Flags().StringVarP(&inputFile, "input", "i", "", "Path to input file for the solution (default is the day's configured input file)")
+	submitCmd.Flags().DurationVar(&timeout, "timeout", cfg.Timeout, "Maximum time allowed to solve the puzzle, or 0 for no limit")
+	submitCmd.Flags().StringVar(&submitCfg.BaseURL, "base-url", defaultBaseURL(), "Base URL of the puzzle server (default can be set with AOC_BASE_URL)")
+	submitCmd.Flags().StringVar(&sessionFile, "session-file", cfg.SessionFile, "Path to file containing the session token, used if AOC_SESSION is unset")
+	submitCmd.Flags().StringVar(&logFile, "log-file", submit.DefaultLogPath, "Path to the log of submitted answers & their verdicts")
+
+	_ = submitCmd.MarkFlagRequired("part") // only fails if the flag doesn't exist
+
+	return submitCmd
+}
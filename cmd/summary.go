@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+var (
+	ErrDaysFailed = errors.New("some days failed")
+)
+
+// dayStatus is the overall status of a day in a summary.
+type dayStatus string
+
+const (
+	dayPassed  dayStatus = "PASSED"
+	dayFailed  dayStatus = "FAILED"
+	daySkipped dayStatus = "SKIPPED" // no input file or solution yet
+)
+
+// daySummary describes the outcome of solving a single day.
+type daySummary struct {
+	Day      int
+	Title    string
+	Status   dayStatus
+	Duration time.Duration
+	Cached   bool   // answers were read from the cache
+	Details  string // what went wrong, if the day didn't pass
+}
+
+// summarize returns the summary of the given day's outcome. A day passes if all of its
+// implemented parts were solved, and is skipped if its input file doesn't exist or none
+// of its parts are implemented.
+func summarize(entry solutions.Entry, o dayOutcome) daySummary {
+	s := daySummary{Day: entry.Day, Title: entry.Metadata.Title, Status: dayPassed, Duration: o.duration, Cached: o.cached}
+
+	switch {
+	case errors.Is(o.err, os.ErrNotExist):
+		s.Status, s.Details = daySkipped, "no input file"
+		return s
+	case o.err != nil:
+		s.Status, s.Details = dayFailed, o.err.Error()
+		return s
+	}
+
+	var details []string
+	implemented := 0
+	for _, part := range o.result.Parts {
+		if !errors.Is(part.Err, solutions.ErrNotImplemented) {
+			implemented++
+		}
+
+		if part.Err != nil {
+			if !errors.Is(part.Err, solutions.ErrNotImplemented) {
+				s.Status = dayFailed
+			}
+
+			details = append(details, fmt.Sprintf("part %d: %v", part.Number, part.Err))
+		}
+	}
+
+	if implemented == 0 && len(o.result.Parts) > 0 {
+		s.Status, s.Details = daySkipped, solutions.ErrNotImplemented.Error()
+		return s
+	}
+
+	s.Details = strings.Join(details, "; ")
+	return s
+}
+
+// writeSummary writes a table of the given summaries to w, followed by the number of days
+// with each status. It returns an error wrapping ErrDaysFailed if any day failed.
+func writeSummary(w io.Writer, summaries []daySummary) error {
+	counts := map[dayStatus]int{}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "DAY\tTITLE\tSTATUS\tTIME\tDETAILS")
+
+	for _, s := range summaries {
+		counts[s.Status]++
+
+		duration := "-"
+		if s.Status != daySkipped {
+			duration = s.Duration.Round(time.Microsecond).String() + cachedMarker(s.Cached)
+		}
+
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\n", s.Day, s.Title, s.Status, duration, s.Details)
+	}
+
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\n%d passed, %d failed, %d skipped\n", counts[dayPassed], counts[dayFailed], counts[daySkipped])
+
+	if failed := counts[dayFailed]; failed > 0 {
+		return fmt.Errorf("%w (%d of %d)", ErrDaysFailed, failed, len(summaries))
+	}
+
+	return nil
+}
+
+// cachedMarker returns the marker added to the durations of cached outcomes, if cached.
+func cachedMarker(cached bool) string {
+	if cached {
+		return " (cached)"
+	}
+
+	return ""
+}
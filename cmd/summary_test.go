@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		outcome dayOutcome
+
+		// outputs
+		expected daySummary
+	}
+
+	entry := solutions.Entry{Year: 2020, Day: 12, Metadata: solutions.Metadata{Title: "Rain Risk"}}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		if diff := cmp.Diff(cfg.expected, summarize(entry, cfg.outcome)); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+	}
+
+	tests := map[string]Test{
+		"passed": {
+			outcome: dayOutcome{
+				result:   solutions.Result{Parts: []solutions.Part{{Number: 1, Answer: 1}, {Number: 2, Answer: 2}}},
+				duration: time.Millisecond,
+			},
+			expected: daySummary{Day: 12, Title: "Rain Risk", Status: dayPassed, Duration: time.Millisecond},
+		},
+		"passed: cached": {
+			outcome: dayOutcome{
+				result:   solutions.Result{Parts: []solutions.Part{{Number: 1, Answer: "1"}}},
+				duration: time.Millisecond,
+				cached:   true,
+			},
+			expected: daySummary{Day: 12, Title: "Rain Risk", Status: dayPassed, Duration: time.Millisecond, Cached: true},
+		},
+		"passed with unimplemented part": {
+			outcome: dayOutcome{
+				result: solutions.Result{Parts: []solutions.Part{{Number: 1, Answer: 1}, {Number: 2, Err: solutions.ErrNotImplemented}}},
+			},
+			expected: daySummary{Day: 12, Title: "Rain Risk", Status: dayPassed, Details: "part 2: not implemented"},
+		},
+		"failed part": {
+			outcome: dayOutcome{
+				result: solutions.Result{Parts: []solutions.Part{
+					{Number: 1, Err: fmt.Errorf("%w (boom)", solutions.ErrPanicked)},
+					{Number: 2, Answer: 2},
+				}},
+			},
+			expected: daySummary{Day: 12, Title: "Rain Risk", Status: dayFailed, Details: "part 1: solution panicked (boom)"},
+		},
+		"failed day": {
+			outcome:  dayOutcome{err: errors.New("invalid input")},
+			expected: daySummary{Day: 12, Title: "Rain Risk", Status: dayFailed, Details: "invalid input"},
+		},
+		"skipped: no input": {
+			outcome:  dayOutcome{err: fmt.Errorf("failed to open file (%w)", os.ErrNotExist)},
+			expected: daySummary{Day: 12, Title: "Rain Risk", Status: daySkipped, Details: "no input file"},
+		},
+		"skipped: not implemented": {
+			outcome: dayOutcome{
+				result: solutions.Result{Parts: []solutions.Part{{Number: 1, Err: solutions.ErrNotImplemented}, {Number: 2, Err: solutions.ErrNotImplemented}}},
+			},
+			expected: daySummary{Day: 12, Title: "Rain Risk", Status: daySkipped, Details: "not implemented"},
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	t.Parallel()
+
+	summaries := []daySummary{
+		{Day: 1, Status: dayPassed},
+		{Day: 2, Status: dayFailed},
+		{Day: 3, Status: daySkipped},
+	}
+
+	if err := writeSummary(ioutil.Discard, summaries); !errors.Is(err, ErrDaysFailed) {
+		t.Errorf("Got %v, expected %v", err, ErrDaysFailed)
+	}
+
+	if err := writeSummary(ioutil.Discard, summaries[:1]); err != nil {
+		t.Errorf("Got %v, expected nil", err)
+	}
+}
+
+func TestCheckParts(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		parts []solutions.Part
+
+		// outputs
+		expectedErr error
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		if err := checkParts(solutions.Result{Parts: cfg.parts}); !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+	}
+
+	tests := map[string]Test{
+		"ok: all solved":       {parts: []solutions.Part{{Number: 1, Answer: 1}, {Number: 2, Answer: 2}}},
+		"ok: not implemented":  {parts: []solutions.Part{{Number: 1, Answer: 1}, {Number: 2, Err: solutions.ErrNotImplemented}}},
+		"error: part failed":   {parts: []solutions.Part{{Number: 1, Err: errors.New("no entries sum to 2020")}}, expectedErr: ErrPartsFailed},
+		"error: part panicked": {parts: []solutions.Part{{Number: 1, Answer: 1}, {Number: 2, Err: solutions.ErrPanicked}}, expectedErr: ErrPartsFailed},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+	"github.com/segwin/adventofcode-2020/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+func newTUICommand(year int, cfg config.Config) *cobra.Command {
+	var (
+		inputDir string
+		timeout  time.Duration
+	)
+
+	tuiCmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Browse the solutions & run them interactively",
+		Long: `Show an interactive terminal UI listing every day with the status & timing of its
+last run. Select a day to run all of its parts or a single part, switch between its
+input & example files, and scroll through its output.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return tui.Run(cmd.Context(), os.Stdin, cmd.OutOrStdout(), tui.Config{
+				Entries: solutions.List(year),
+				Inputs: func(entry solutions.Entry) []string {
+					return dayInputs(cfg, inputDir, cmd.Flags().Changed("input"), entry.Year, entry.Day)
+				},
+				Solve: func(ctx context.Context, solution solutions.Solution, path string, parts ...int) (solutions.Result, time.Duration, error) {
+					return solveFile(ctx, solution, path, timeout, parts...)
+				},
+				Render: printResult,
+			})
+		},
+	}
+
+	tuiCmd.Flags().StringVarP(&inputDir, "input", "i", cfg.Inputs, "Path to directory containing all input files, structured as <dir>/<year>/day<N>/{input,example*}")
+	tuiCmd.Flags().DurationVar(&timeout, "timeout", cfg.Timeout, "Maximum time allowed to solve a puzzle, or 0 for no limit")
+
+	return tuiCmd
+}
+
+// dayInputs returns the paths to a day's input file followed by its example files, if
+// any. Files that don't exist are omitted.
+func dayInputs(cfg config.Config, inputDir string, inputSet bool, year, day int) (paths []string) {
+	input := dayInputFile(cfg, inputDir, inputSet, year, day)
+	if _, err := os.Stat(input); err == nil {
+		paths = append(paths, input)
+	}
+
+	examples, _ := filepath.Glob(dayFile(inputDir, year, day, "example*")) // only fails on bad patterns
+	sort.Strings(examples)
+
+	for _, example := range examples {
+		if !strings.HasSuffix(example, ".expected") {
+			paths = append(paths, example)
+		}
+	}
+
+	return paths
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ErrVerificationFailed = errors.New("verification failed")
+)
+
+const (
+	statusPass  = "PASS"
+	statusFail  = "FAIL"
+	statusError = "ERROR"
+	statusSkip  = "SKIP"
+)
+
+func newVerifyCommand(year int, cfg config.Config) *cobra.Command {
+	var inputDir string
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify [days...]",
+		Short: "Check the answers of the given days (or all days) against recorded answers",
+		Long: `Run the given days (or all days if none are given) and compare each part's
+answer to the expected answers recorded in <dir>/<year>/day<N>/answers. The answers file
+contains one answer per line, in part order; an empty line skips that part.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := selectEntries(year, args)
+			if err != nil {
+				return err
+			}
+
+			table := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(table, "DAY\tPART\tEXPECTED\tGOT\tSTATUS")
+
+			failures := 0
+			for _, entry := range entries {
+				day := entry.Day
+
+				expected, err := readAnswers(cmd.Context(), dayFile(inputDir, year, day, "answers"))
+				if err != nil {
+					return fmt.Errorf("day %d: %w", day, err)
+				}
+
+				result, _, err := solveFile(dayContext(cmd.Context(), year, day), entry.Solution, dayInputFile(cfg, inputDir, cmd.Flags().Changed("input"), year, day), dayTimeout(cfg, cfg.Timeout, false, year, day))
+				if err != nil {
+					failures++
+					fmt.Fprintf(table, "%d\t-\t-\t%v\t%s\n", day, err, statusError)
+					continue
+				}
+
+				for _, part := range result.Parts {
+					var want string
+					if part.Number <= len(expected) {
+						want = expected[part.Number-1]
+					}
+
+					got, status := checkAnswer(part, want)
+					if status == statusFail || status == statusError {
+						failures++
+					}
+
+					fmt.Fprintf(table, "%d\t%d\t%s\t%s\t%s\n", day, part.Number, want, got, status)
+				}
+			}
+
+			if err := table.Flush(); err != nil {
+				return err
+			}
+
+			if failures > 0 {
+				return fmt.Errorf("%w (%d mismatched or failed parts)", ErrVerificationFailed, failures)
+			}
+
+			return nil
+		},
+	}
+
+	verifyCmd.Flags().StringVarP(&inputDir, "input", "i", cfg.Inputs, "Path to directory containing all input & answer files, structured as <dir>/<year>/day<N>/{input,answers}")
+
+	return verifyCmd
+}
+
+// checkAnswer compares a part's answer to the expected one, returning the answer (or
+// error) to report along with the part's status. Parts without an expected answer are
+// skipped, whether they were solved or not.
+func checkAnswer(part solutions.Part, want string) (got, status string) {
+	got, status = fmt.Sprint(part.Answer), statusPass
+	switch {
+	case want == "":
+		status = statusSkip
+		if part.Err != nil {
+			got = part.Err.Error()
+		}
+	case part.Err != nil:
+		got, status = part.Err.Error(), statusError
+	case got != want:
+		status = statusFail
+	}
+
+	return got, status
+}
+
+// readAnswers reads the expected answers from the file at path, one per part. A
+// missing file is treated as having no expected answers.
+func readAnswers(ctx context.Context, path string) (answers []string, err error) {
+	scanner, err := input.NewFileScanner(ctx, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	defer scanner.Close()
+
+	return input.ReadLines(scanner)
+}
+
+// dayFile returns the path to the given file within a day's input directory.
+func dayFile(inputDir string, year, day int, name string) string {
+	return strings.Join([]string{inputDir, strconv.Itoa(year), fmt.Sprintf("day%d", day), name}, "/")
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+// verifyTestYear is only used by the solution registered for these tests.
+const verifyTestYear = 1
+
+func init() {
+	solutions.Register(verifyTestYear, 1, &failingSolution{}, solutions.Metadata{Title: "Failing"})
+}
+
+func TestCheckAnswer(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		part solutions.Part
+		want string
+
+		// outputs
+		expectedGot    string
+		expectedStatus string
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		got, status := checkAnswer(cfg.part, cfg.want)
+		if got != cfg.expectedGot {
+			t.Errorf("Got %v, expected %v", got, cfg.expectedGot)
+		}
+
+		if status != cfg.expectedStatus {
+			t.Errorf("Got %v, expected %v", status, cfg.expectedStatus)
+		}
+	}
+
+	tests := map[string]Test{
+		"pass": {
+			part:           solutions.Part{Answer: 953},
+			want:           "953",
+			expectedGot:    "953",
+			expectedStatus: statusPass,
+		},
+		"fail": {
+			part:           solutions.Part{Answer: 954},
+			want:           "953",
+			expectedGot:    "954",
+			expectedStatus: statusFail,
+		},
+		"error": {
+			part:           solutions.Part{Err: errors.New("no answer")},
+			want:           "953",
+			expectedGot:    "no answer",
+			expectedStatus: statusError,
+		},
+		"skip: solved": {
+			part:           solutions.Part{Answer: 953},
+			expectedGot:    "953",
+			expectedStatus: statusSkip,
+		},
+		"skip: not implemented": {
+			part:           solutions.Part{Err: solutions.ErrNotImplemented},
+			expectedGot:    "not implemented",
+			expectedStatus: statusSkip,
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+func TestReadAnswers(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		contents *string // nil if the file is missing
+
+		// outputs
+		expected []string
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		dir, err := ioutil.TempDir("", "aoc-answers")
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "answers")
+		if cfg.contents != nil {
+			if err := ioutil.WriteFile(path, []byte(*cfg.contents), 0644); err != nil {
+				t.Fatalf("Got %v, expected nil", err)
+			}
+		}
+
+		got, err := readAnswers(context.Background(), path)
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		if diff := cmp.Diff(cfg.expected, got); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+	}
+
+	str := func(s string) *string { return &s }
+
+	tests := map[string]Test{
+		"missing":          {contents: nil, expected: nil},
+		"all parts":        {contents: str("953\n615\n"), expected: []string{"953", "615"}},
+		"skipped part":     {contents: str("\n615\n"), expected: []string{"", "615"}},
+		"no trailing line": {contents: str("953"), expected: []string{"953"}},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		answers string
+		noInput bool
+
+		// outputs
+		expectedStatuses []string // of each row, in order
+		expectedErr      error
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		dir, err := ioutil.TempDir("", "aoc-verify")
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		dayDir := filepath.Join(dir, "1", "day1")
+		if err := os.MkdirAll(dayDir, 0755); err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(dayDir, "answers"), []byte(cfg.answers), 0644); err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		if !cfg.noInput {
+			if err := ioutil.WriteFile(filepath.Join(dayDir, "input"), []byte("input\n"), 0644); err != nil {
+				t.Fatalf("Got %v, expected nil", err)
+			}
+		}
+
+		var out bytes.Buffer
+		verifyCmd := newVerifyCommand(verifyTestYear, config.Config{})
+		verifyCmd.SetArgs([]string{"--input", dir})
+		verifyCmd.SetOut(&out)
+		verifyCmd.SilenceUsage, verifyCmd.SilenceErrors = true, true
+
+		if err := verifyCmd.ExecuteContext(context.Background()); !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+
+		var statuses []string
+		for _, row := range strings.Split(strings.TrimSpace(out.String()), "\n")[1:] {
+			fields := strings.Fields(row)
+			statuses = append(statuses, fields[len(fields)-1])
+		}
+
+		if diff := cmp.Diff(cfg.expectedStatuses, statuses); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+	}
+
+	// failingSolution's parts answer 1, panic, fail & aren't implemented
+	tests := map[string]Test{
+		"pass": {
+			answers:          "1\n",
+			expectedStatuses: []string{statusPass, statusSkip, statusSkip, statusSkip},
+		},
+		"fail": {
+			answers:          "2\n",
+			expectedStatuses: []string{statusFail, statusSkip, statusSkip, statusSkip},
+			expectedErr:      ErrVerificationFailed,
+		},
+		"error: part": {
+			answers:          "1\n\n3\n",
+			expectedStatuses: []string{statusPass, statusSkip, statusError, statusSkip},
+			expectedErr:      ErrVerificationFailed,
+		},
+		"error: panic": {
+			answers:          "1\n2\n",
+			expectedStatuses: []string{statusPass, statusError, statusSkip, statusSkip},
+			expectedErr:      ErrVerificationFailed,
+		},
+		"error: no input": {
+			answers:          "1\n",
+			noInput:          true,
+			expectedStatuses: []string{statusError},
+			expectedErr:      ErrVerificationFailed,
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
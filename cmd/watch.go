@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/gomod"
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+	"github.com/segwin/adventofcode-2020/internal/watch"
+)
+
+var (
+	ErrBuildFailed = errors.New("build failed")
+)
+
+// watchDay solves the given day, then solves it again each time its package's source or
+// its input file changes. As the solution's code may change, each run uses a fresh
+// build of the app. The answers of each run are compared to those of the previous one.
+func watchDay(ctx context.Context, w io.Writer, entry solutions.Entry, inputFile string, timeout, interval time.Duration, parts []int) error {
+	if inputFile == input.Stdin {
+		return fmt.Errorf("%w (can't watch standard input)", ErrInvalidFlag)
+	}
+
+	root, err := gomod.Root()
+	if err != nil {
+		return err
+	}
+
+	inputPath, err := filepath.Abs(inputFile)
+	if err != nil {
+		return err
+	}
+
+	sourceDir := solutionDir(root, entry)
+	watched := []string{sourceDir, inputPath}
+
+	buildDir, err := ioutil.TempDir("", "aoc-watch")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(buildDir)
+
+	snapshot, err := watch.Take(watched...)
+	if err != nil {
+		return err
+	}
+
+	var previous []record
+	for {
+		fmt.Fprintf(w, "[%s] Running %d day %d\n", time.Now().Format("15:04:05"), entry.Year, entry.Day)
+
+		records, err := runBuild(ctx, root, filepath.Join(buildDir, "aoc"), entry, inputPath, timeout, parts)
+		if ctx.Err() != nil {
+			return nil // interrupted while building or solving
+		} else if err != nil {
+			fmt.Fprintf(w, "\nERROR: %v\n", err)
+		} else {
+			for _, line := range diffAnswers(previous, records) {
+				fmt.Fprintln(w, line)
+			}
+
+			previous = records
+		}
+
+		fmt.Fprintf(w, "\nWatching %s & %s for changes...\n", relativeTo(root, sourceDir), relativeTo(root, inputPath))
+
+		var changed []string
+		snapshot, changed, err = watch.Wait(ctx, snapshot, interval, watched...)
+		if errors.Is(err, context.Canceled) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		for i := range changed {
+			changed[i] = relativeTo(root, changed[i])
+		}
+
+		fmt.Fprintf(w, "\nChanged: %s\n", strings.Join(changed, ", "))
+	}
+}
+
+// runBuild builds the app from the module at root into binary, then uses it to solve the
+// given day, returning the records it output.
+func runBuild(ctx context.Context, root, binary string, entry solutions.Entry, inputPath string, timeout time.Duration, parts []int) ([]record, error) {
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, ".")
+	build.Dir = root
+
+	if output, err := build.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("%w (%v)\n%s", ErrBuildFailed, err, bytes.TrimSpace(output))
+	}
+
+	args := []string{strconv.Itoa(entry.Year), fmt.Sprintf("day%d", entry.Day), "--input", inputPath, "--output", jsonOutput, "--timeout", timeout.String()}
+	for _, part := range parts {
+		args = append(args, "--part", strconv.Itoa(part))
+	}
+
+	var stdout, stderr bytes.Buffer
+	run := exec.CommandContext(ctx, binary, args...)
+	run.Stdout, run.Stderr = &stdout, &stderr
+
+	// the app exits with an error if the day couldn't be solved, but still reports it in
+	// its output
+	runErr := run.Run()
+
+	var records []record
+	if err := json.Unmarshal(stdout.Bytes(), &records); err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("%v\n%s", runErr, bytes.TrimSpace(stderr.Bytes()))
+		}
+
+		return nil, fmt.Errorf("failed to decode answers (%w)", err)
+	}
+
+	return records, nil
+}
+
+// diffAnswers describes the given records along with the time each one took, comparing
+// each part's answer to the one found in previous (if any).
+func diffAnswers(previous, current []record) (lines []string) {
+	before := map[int]record{}
+	for _, r := range previous {
+		before[r.Part] = r
+	}
+
+	for _, r := range current {
+		label := fmt.Sprintf("PART %d", r.Part)
+		if r.Part == 0 {
+			label = "DAY"
+		}
+
+		duration := time.Duration(r.DurationMS * float64(time.Millisecond)).Round(time.Microsecond)
+		if r.Error != "" {
+			lines = append(lines, fmt.Sprintf("  %s: ERROR: %s [%s]", label, r.Error, duration))
+			continue
+		}
+
+		change := "(new)"
+		if old, ok := before[r.Part]; ok {
+			switch {
+			case old.Error != "":
+				change = "(fixed)"
+			case old.Answer == r.Answer:
+				change = "(unchanged)"
+			default:
+				change = fmt.Sprintf("(changed, was %s)", old.Answer)
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("  %s: %s %s [%s]", label, r.Answer, change, duration))
+	}
+
+	return lines
+}
+
+// solutionDir returns the directory holding the sources of the given entry in the module
+// at root.
+func solutionDir(root string, entry solutions.Entry) string {
+	return filepath.Join(root, "internal", "solutions", strconv.Itoa(entry.Year), fmt.Sprintf("day%d", entry.Day))
+}
+
+// relativeTo returns path relative to root if possible, or path itself otherwise.
+func relativeTo(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+
+	return path
+}
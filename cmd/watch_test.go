@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffAnswers(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		previous []record
+		current  []record
+
+		// outputs
+		expected []string
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		if diff := cmp.Diff(cfg.expected, diffAnswers(cfg.previous, cfg.current)); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+	}
+
+	tests := map[string]Test{
+		"first run": {
+			current: []record{
+				{Day: 5, Part: 1, Answer: "953", DurationMS: 1.5},
+				{Day: 5, Part: 2, Answer: "615", DurationMS: 1.5},
+			},
+			expected: []string{
+				"  PART 1: 953 (new) [1.5ms]",
+				"  PART 2: 615 (new) [1.5ms]",
+			},
+		},
+		"changes": {
+			previous: []record{
+				{Day: 5, Part: 1, Answer: "953"},
+				{Day: 5, Part: 2, Error: "not implemented"},
+			},
+			current: []record{
+				{Day: 5, Part: 1, Answer: "954", DurationMS: 2},
+				{Day: 5, Part: 2, Answer: "615", DurationMS: 0.5},
+			},
+			expected: []string{
+				"  PART 1: 954 (changed, was 953) [2ms]",
+				"  PART 2: 615 (fixed) [500µs]",
+			},
+		},
+		"errors": {
+			previous: []record{
+				{Day: 5, Part: 1, Answer: "953"},
+			},
+			current: []record{
+				{Day: 5, Error: "solution timed out", DurationMS: 10},
+			},
+			expected: []string{
+				"  DAY: ERROR: solution timed out [10ms]",
+			},
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
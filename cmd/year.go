@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/segwin/adventofcode-2020/internal/config"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+	"github.com/spf13/cobra"
+)
+
+// defaultYear is the event year whose commands are also available directly under the
+// root command unless configured otherwise, e.g. "aoc day5" is an alias for
+// "aoc 2020 day5".
+const defaultYear = 2020
+
+func newYearCommand(year int, cfg config.Config) *cobra.Command {
+	yearCmd := &cobra.Command{
+		Use:   strconv.Itoa(year),
+		Short: fmt.Sprintf("Run the solutions for the Advent of Code %d event", year),
+		Args:  cobra.NoArgs,
+	}
+
+	addYearCommands(yearCmd, year, cfg)
+
+	return yearCmd
+}
+
+func newYearCommands(cfg config.Config) (commands []*cobra.Command) {
+	for _, year := range solutions.Years() {
+		commands = append(commands, newYearCommand(year, cfg))
+	}
+
+	return commands
+}
+
+// addYearCommands adds all commands operating on the given year's solutions to parent,
+// using cfg for the defaults of their flags.
+func addYearCommands(parent *cobra.Command, year int, cfg config.Config) {
+	for _, cmd := range newDayCommands(year, cfg) {
+		parent.AddCommand(cmd)
+	}
+
+	parent.AddCommand(newAllCommand(year, cfg))
+	parent.AddCommand(newVerifyCommand(year, cfg))
+	parent.AddCommand(newBenchCommand(year, cfg))
+	parent.AddCommand(newFetchCommand(year, cfg))
+	parent.AddCommand(newSubmitCommand(year, cfg))
+	parent.AddCommand(newLintCommand(year, cfg))
+	parent.AddCommand(newListCommand(year))
+	parent.AddCommand(newHistoryCommand(year))
+	parent.AddCommand(newReportCommand(year, cfg))
+	parent.AddCommand(newTUICommand(year, cfg))
+}
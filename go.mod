@@ -5,4 +5,6 @@ go 1.15
 require (
 	github.com/google/go-cmp v0.5.4
 	github.com/spf13/cobra v1.1.1
+	golang.org/x/term v0.0.0-20201126162022-7de9c90e9dd1
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,179 @@
+// Package cache stores the answers of solved days, keyed by everything that could change
+// them: the input file's contents & the solution's code.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultDir is the default location of the cache, relative to the working directory.
+const DefaultDir = ".aoc/cache"
+
+// Entry holds the answers cached for a day.
+type Entry struct {
+	Year       int       `json:"year"`
+	Day        int       `json:"day"`
+	Created    time.Time `json:"created"`
+	DurationMS float64   `json:"duration_ms"` // time originally taken to solve the day
+	Parts      []Part    `json:"parts"`
+}
+
+// Part is the cached answer to a single part.
+type Part struct {
+	Number     int     `json:"part"`
+	Answer     string  `json:"answer"`
+	Label      string  `json:"label,omitempty"`
+	DurationMS float64 `json:"duration_ms"` // time originally taken to solve the part
+}
+
+// Key returns the key of a day's answers given the hex-encoded hash of its input file &
+// the version of its code (see SourceVersion).
+func Key(year, day int, inputHash, codeVersion string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%d/%d/%s/%s", year, day, inputHash, codeVersion)))
+	return hex.EncodeToString(hash[:])
+}
+
+// Store is a directory holding one JSON file per cached entry.
+type Store struct {
+	Dir string
+}
+
+// Get returns the entry cached under the given key, if any.
+func (s *Store) Get(key string) (entry Entry, ok bool, err error) {
+	data, err := ioutil.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return Entry{}, false, nil
+	} else if err != nil {
+		return Entry{}, false, fmt.Errorf("failed to read cache (%w)", err)
+	}
+
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Entry{}, false, nil // treat corrupted entries as missing, they'll be replaced
+	}
+
+	return entry, true, nil
+}
+
+// Put caches the given entry under the given key, replacing any existing entry.
+func (s *Store) Put(key string, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory (%w)", err)
+	}
+
+	// write to a temporary file first so concurrent readers never see partial entries
+	tmp, err := ioutil.TempFile(s.Dir, ".entry-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache (%w)", err)
+	}
+
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache (%w)", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache (%w)", err)
+	}
+
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// Clear removes all cached entries, returning how many there were.
+func (s *Store) Clear() (removed int, err error) {
+	paths, err := s.entries()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("failed to clear cache (%w)", err)
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
+
+// Stats describes the contents of a cache.
+type Stats struct {
+	Entries int
+	Bytes   int64
+	Days    map[int][]int // cached days, by year
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+// Stats returns statistics about the cached entries.
+func (s *Store) Stats() (Stats, error) {
+	stats := Stats{Days: map[int][]int{}}
+
+	paths, err := s.entries()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	seen := map[[2]int]bool{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return Stats{}, fmt.Errorf("failed to read cache (%w)", err)
+		}
+
+		entry, ok, err := s.Get(strings.TrimSuffix(filepath.Base(path), ".json"))
+		if err != nil {
+			return Stats{}, err
+		} else if !ok {
+			continue
+		}
+
+		stats.Entries++
+		stats.Bytes += info.Size()
+
+		if day := [2]int{entry.Year, entry.Day}; !seen[day] {
+			seen[day] = true
+			stats.Days[entry.Year] = append(stats.Days[entry.Year], entry.Day)
+		}
+
+		if stats.Oldest.IsZero() || entry.Created.Before(stats.Oldest) {
+			stats.Oldest = entry.Created
+		}
+
+		if entry.Created.After(stats.Newest) {
+			stats.Newest = entry.Created
+		}
+	}
+
+	return stats, nil
+}
+
+func (s *Store) path(key string) string {
+	return filepath.Join(s.Dir, key+".json")
+}
+
+// entries returns the paths of all cached entries. A missing cache has no entries.
+func (s *Store) entries() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(s.Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "aoc-cache-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := &Store{Dir: filepath.Join(dir, "cache")} // created on first write
+
+	key := Key(2020, 5, "input", "code")
+	if _, ok, err := store.Get(key); ok || err != nil {
+		t.Fatalf("Got %v (%v), expected a miss", ok, err)
+	}
+
+	created := time.Date(2020, 12, 5, 6, 0, 0, 0, time.UTC)
+	entries := map[string]Entry{
+		key:                               {Year: 2020, Day: 5, Created: created, DurationMS: 1.5, Parts: []Part{{Number: 1, Answer: "953", Label: "Highest seat ID"}, {Number: 2, Answer: "615"}}},
+		Key(2020, 5, "input", "new code"): {Year: 2020, Day: 5, Created: created.Add(time.Hour), Parts: []Part{{Number: 1, Answer: "953"}}},
+		Key(2020, 6, "input", "code"):     {Year: 2020, Day: 6, Created: created.Add(time.Minute)},
+	}
+
+	for key, entry := range entries {
+		if err := store.Put(key, entry); err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+	}
+
+	got, ok, err := store.Get(key)
+	if !ok || err != nil {
+		t.Fatalf("Got %v (%v), expected a hit", ok, err)
+	}
+
+	if diff := cmp.Diff(entries[key], got); diff != "" {
+		t.Errorf("Unexpected diff:\n%v", diff)
+	}
+
+	stats, err := store.Stats()
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	if stats.Entries != 3 || stats.Bytes == 0 {
+		t.Errorf("Got %d entries (%d bytes), expected 3", stats.Entries, stats.Bytes)
+	}
+
+	if !stats.Oldest.Equal(created) || !stats.Newest.Equal(created.Add(time.Hour)) {
+		t.Errorf("Got %v to %v, expected %v to %v", stats.Oldest, stats.Newest, created, created.Add(time.Hour))
+	}
+
+	if len(stats.Days[2020]) != 2 {
+		t.Errorf("Got %v, expected days 5 & 6", stats.Days[2020])
+	}
+
+	removed, err := store.Clear()
+	if removed != 3 || err != nil {
+		t.Errorf("Got %d (%v), expected 3", removed, err)
+	}
+
+	if _, ok, err := store.Get(key); ok || err != nil {
+		t.Errorf("Got %v (%v), expected a miss", ok, err)
+	}
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	key := Key(2020, 5, "input", "code")
+
+	for name, other := range map[string]string{
+		"year":  Key(2021, 5, "input", "code"),
+		"day":   Key(2020, 6, "input", "code"),
+		"input": Key(2020, 5, "other input", "code"),
+		"code":  Key(2020, 5, "input", "other code"),
+	} {
+		if other == key {
+			t.Errorf("Got the same key with a different %s", name)
+		}
+	}
+
+	if again := Key(2020, 5, "input", "code"); again != key {
+		t.Errorf("Got %v, expected %v", again, key)
+	}
+}
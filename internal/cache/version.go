@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go/build"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/gomod"
+)
+
+// SourceVersion returns a hash identifying the code of the package in dir, i.e. the
+// contents of its Go files & those of every package it imports from the same module, along
+// with go.mod, go.sum & the Go version. root is the module's root directory. The
+// modification time of the newest file hashed is also returned, so that callers can tell
+// whether the running executable was built from these sources.
+func SourceVersion(root, dir string) (version string, modTime time.Time, err error) {
+	modulePath, err := gomod.ModulePath(root)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	files := []string{filepath.Join(root, "go.mod"), filepath.Join(root, "go.sum")}
+
+	// collect the package's files & those of its in-module dependencies
+	visited := map[string]bool{}
+	pending := []string{dir}
+	for len(pending) > 0 {
+		dir := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if visited[dir] {
+			continue
+		}
+
+		visited[dir] = true
+
+		pkg, err := build.ImportDir(dir, 0)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to read package sources (%w)", err)
+		}
+
+		for _, names := range [][]string{pkg.GoFiles, pkg.CgoFiles, pkg.SFiles} {
+			for _, name := range names {
+				files = append(files, filepath.Join(dir, name))
+			}
+		}
+
+		for _, path := range pkg.Imports {
+			if path == modulePath || strings.HasPrefix(path, modulePath+"/") {
+				pending = append(pending, filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(path, modulePath))))
+			}
+		}
+	}
+
+	sort.Strings(files)
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n", runtime.Version())
+
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) && filepath.Base(path) == "go.sum" {
+			continue // modules without dependencies have no go.sum
+		} else if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to read package sources (%w)", err)
+		}
+
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+
+		fmt.Fprintf(hash, "%s %d\n", filepath.ToSlash(rel), info.Size())
+		if err := hashFile(hash, path); err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to read package sources (%w)", err)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), modTime, nil
+}
+
+// ExecutableVersion returns a hash of the running executable. It identifies the code of
+// every solution at once, for use when their sources aren't available.
+func ExecutableVersion() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	if err := hashFile(hash, path); err != nil {
+		return "", fmt.Errorf("failed to read executable (%w)", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func hashFile(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = io.Copy(w, file)
+	return err
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSourceVersion(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		changed string // path of the file changed, relative to the module root
+
+		// outputs
+		expectChange bool
+	}
+
+	files := map[string]string{
+		"go.mod":              "module example.com/aoc\n\ngo 1.15\n",
+		"input/input.go":      "package input\n\nfunc Lines() {}\n",
+		"input/input_test.go": "package input\n",
+		"unused/unused.go":    "package unused\n",
+		"day1/solution.go":    "package day1\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/aoc/input\"\n)\n\nfunc Solve() { input.Lines(); fmt.Println() }\n",
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		root, err := ioutil.TempDir("", "aoc-cache-test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(root)
+
+		for path, contents := range files {
+			path = filepath.Join(root, path)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		before, _, err := SourceVersion(root, filepath.Join(root, "day1"))
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		path := filepath.Join(root, cfg.changed)
+		if err := ioutil.WriteFile(path, []byte(files[cfg.changed]+"\n// changed\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		after, _, err := SourceVersion(root, filepath.Join(root, "day1"))
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		if changed := before != after; changed != cfg.expectChange {
+			t.Errorf("Got changed=%v, expected %v", changed, cfg.expectChange)
+		}
+	}
+
+	tests := map[string]Test{
+		"solution changed":       {changed: "day1/solution.go", expectChange: true},
+		"dependency changed":     {changed: "input/input.go", expectChange: true},
+		"go.mod changed":         {changed: "go.mod", expectChange: true},
+		"test file changed":      {changed: "input/input_test.go", expectChange: false},
+		"unused package changed": {changed: "unused/unused.go", expectChange: false},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
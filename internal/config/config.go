@@ -0,0 +1,216 @@
+// Package config loads the CLI's settings from configuration files & environment
+// variables.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	ErrInvalidFile = errors.New("invalid configuration file")
+	ErrInvalidEnv  = errors.New("invalid environment variable")
+)
+
+// ProjectFile is the name of the project-local configuration file, looked up in the
+// working directory.
+const ProjectFile = ".aoc.yaml"
+
+// Config holds the settings used by the CLI, which serve as the defaults of the
+// corresponding flags.
+type Config struct {
+	// Year is the event year whose commands are available without a year prefix.
+	Year int `yaml:"year"`
+
+	// Inputs is the directory containing all input files, as <Inputs>/<year>/day<N>/input.
+	Inputs string `yaml:"inputs"`
+
+	// Output is the format results are written in.
+	Output string `yaml:"output"`
+
+	// SessionFile is the path to the file containing the session token.
+	SessionFile string `yaml:"session-file"`
+
+	// Timeout is the maximum time allowed to solve a day, or 0 for no limit.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Days overrides the settings of individual days of Year, by day number.
+	Days map[int]Day `yaml:"days,omitempty"`
+
+	// Sources lists where the settings were loaded from, in order.
+	Sources []string `yaml:"-"`
+}
+
+// Day holds the settings that can be overridden for a single day.
+type Day struct {
+	// Input is the path to the day's input file.
+	Input string `yaml:"input,omitempty"`
+
+	// Timeout is the maximum time allowed to solve the day.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// Day returns the overrides for the given day, if any. Overrides only apply to days of
+// the configured year.
+func (c Config) Day(year, day int) Day {
+	if year != c.Year {
+		return Day{}
+	}
+
+	return c.Days[day]
+}
+
+// Paths returns the configuration files to load, in order of increasing precedence: the
+// user's $XDG_CONFIG_HOME/aoc/config.yaml, then the project's .aoc.yaml.
+func Paths() (paths []string) {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		configDir, _ = os.UserConfigDir() // skip the user file if there's no home
+	}
+
+	if configDir != "" {
+		paths = append(paths, filepath.Join(configDir, "aoc", "config.yaml"))
+	}
+
+	return append(paths, ProjectFile)
+}
+
+// Load returns the given defaults overridden by the settings found in each of the given
+// files in turn. Files that don't exist are skipped.
+func Load(defaults Config, paths ...string) (Config, error) {
+	c := defaults
+	c.Days = map[int]Day{}
+	for day, override := range defaults.Days {
+		c.Days[day] = override // don't modify the defaults when merging
+	}
+
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return Config{}, fmt.Errorf("failed to read configuration (%w)", err)
+		}
+
+		var file Config
+		if err := yaml.UnmarshalStrict(data, &file); err != nil {
+			return Config{}, fmt.Errorf("%w (%s: %v)", ErrInvalidFile, path, err)
+		}
+
+		file.Inputs = expandHome(file.Inputs)
+		file.SessionFile = expandHome(file.SessionFile)
+		for day, override := range file.Days {
+			override.Input = expandHome(override.Input)
+			file.Days[day] = override
+		}
+
+		c.merge(file)
+		c.Sources = append(c.Sources, path)
+	}
+
+	return c, nil
+}
+
+// expandHome replaces a leading "~/" in path with the user's home directory, if known.
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(home, path[2:])
+}
+
+// merge overrides the settings of c with those set in other.
+func (c *Config) merge(other Config) {
+	if other.Year != 0 {
+		c.Year = other.Year
+	}
+
+	if other.Inputs != "" {
+		c.Inputs = other.Inputs
+	}
+
+	if other.Output != "" {
+		c.Output = other.Output
+	}
+
+	if other.SessionFile != "" {
+		c.SessionFile = other.SessionFile
+	}
+
+	if other.Timeout != 0 {
+		c.Timeout = other.Timeout
+	}
+
+	for day, override := range other.Days {
+		merged := c.Days[day]
+		if override.Input != "" {
+			merged.Input = override.Input
+		}
+
+		if override.Timeout != 0 {
+			merged.Timeout = override.Timeout
+		}
+
+		if c.Days == nil {
+			c.Days = map[int]Day{}
+		}
+
+		c.Days[day] = merged
+	}
+}
+
+// ApplyEnv overrides the settings of c with the following environment variables, if set:
+// AOC_YEAR, AOC_INPUTS, AOC_OUTPUT, AOC_SESSION_FILE & AOC_TIMEOUT. Variables are looked
+// up with getenv (e.g. os.Getenv).
+func (c *Config) ApplyEnv(getenv func(string) string) error {
+	var env Config
+
+	if value := getenv("AOC_YEAR"); value != "" {
+		year, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%w (AOC_YEAR=%q)", ErrInvalidEnv, value)
+		}
+
+		env.Year = year
+	}
+
+	if value := getenv("AOC_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%w (AOC_TIMEOUT=%q)", ErrInvalidEnv, value)
+		}
+
+		env.Timeout = timeout
+	}
+
+	env.Inputs = getenv("AOC_INPUTS")
+	env.Output = getenv("AOC_OUTPUT")
+	env.SessionFile = getenv("AOC_SESSION_FILE")
+
+	if env.Year != 0 || env.Timeout != 0 || env.Inputs != "" || env.Output != "" || env.SessionFile != "" {
+		c.merge(env)
+		c.Sources = append(c.Sources, "environment")
+	}
+
+	return nil
+}
+
+// Marshal returns the YAML encoding of c, which can be loaded back as a configuration
+// file.
+func (c Config) Marshal() ([]byte, error) {
+	return yaml.Marshal(c)
+}
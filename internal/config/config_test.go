@@ -0,0 +1,146 @@
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		files []string // contents of each file, in order; empty files don't exist
+		env   map[string]string
+
+		// outputs
+		expected    Config // Sources are checked separately
+		expectedErr error
+	}
+
+	defaults := Config{Year: 2020, Inputs: "inputs", Output: "text"}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		dir, err := ioutil.TempDir("", "aoc-config-test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		var paths []string
+		for i, contents := range cfg.files {
+			path := filepath.Join(dir, string(rune('a'+i))+".yaml")
+			paths = append(paths, path)
+
+			if contents == "" {
+				continue
+			}
+
+			if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		got, err := Load(defaults, paths...)
+		if err == nil {
+			err = got.ApplyEnv(func(key string) string { return cfg.env[key] })
+		}
+
+		if !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+
+		if err != nil {
+			return // we're done
+		}
+
+		if diff := cmp.Diff(cfg.expected, got, cmp.FilterPath(func(p cmp.Path) bool { return p.String() == "Sources" }, cmp.Ignore())); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+	}
+
+	tests := map[string]Test{
+		"ok: no files": {
+			files:    []string{"", ""},
+			expected: Config{Year: 2020, Inputs: "inputs", Output: "text", Days: map[int]Day{}},
+		},
+		"ok: project file overrides user file": {
+			files: []string{
+				"inputs: data\ntimeout: 30s\ndays:\n  15: {timeout: 1m}\n  16: {input: big.txt}\n",
+				"output: json\ndays:\n  15: {input: example}\n",
+			},
+			expected: Config{
+				Year:    2020,
+				Inputs:  "data",
+				Output:  "json",
+				Timeout: 30 * time.Second,
+				Days: map[int]Day{
+					15: {Input: "example", Timeout: time.Minute},
+					16: {Input: "big.txt"},
+				},
+			},
+		},
+		"ok: environment overrides files": {
+			files: []string{"year: 2021\ntimeout: 30s\n"},
+			env:   map[string]string{"AOC_TIMEOUT": "5s", "AOC_OUTPUT": "csv"},
+			expected: Config{
+				Year:    2021,
+				Inputs:  "inputs",
+				Output:  "csv",
+				Timeout: 5 * time.Second,
+				Days:    map[int]Day{},
+			},
+		},
+		"error: unknown setting": {
+			files:       []string{"inptus: data\n"},
+			expectedErr: ErrInvalidFile,
+		},
+		"error: invalid environment variable": {
+			env:         map[string]string{"AOC_YEAR": "next"},
+			expectedErr: ErrInvalidEnv,
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+func TestConfigMarshal(t *testing.T) {
+	t.Parallel()
+
+	c := Config{Year: 2020, Inputs: "inputs", Timeout: 90 * time.Second, Days: map[int]Day{15: {Timeout: time.Minute}}}
+
+	data, err := c.Marshal()
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	expected := "year: 2020\ninputs: inputs\noutput: \"\"\nsession-file: \"\"\ntimeout: 1m30s\ndays:\n  15:\n    timeout: 1m0s\n"
+	if diff := cmp.Diff(expected, string(data)); diff != "" {
+		t.Errorf("Unexpected diff:\n%v", diff)
+	}
+}
+
+func TestConfigDay(t *testing.T) {
+	t.Parallel()
+
+	c := Config{Year: 2020, Days: map[int]Day{15: {Timeout: time.Minute}}}
+
+	if got := c.Day(2020, 15); got.Timeout != time.Minute {
+		t.Errorf("Got %v, expected %v", got.Timeout, time.Minute)
+	}
+
+	if got := c.Day(2021, 15); got.Timeout != 0 {
+		t.Errorf("Got %v, expected no override for another year", got.Timeout)
+	}
+}
@@ -0,0 +1,68 @@
+// Package gomod locates the Go module the app is run or tested from & reads its go.mod.
+package gomod
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrNoRoot       = errors.New("no go.mod found in any parent directory")
+	ErrNoModulePath = errors.New("no module declaration found in go.mod")
+)
+
+// Root returns the closest directory containing a go.mod file, starting from the working
+// directory.
+func Root() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	return FindRoot(dir)
+}
+
+// FindRoot returns the closest directory containing a go.mod file, starting from dir.
+func FindRoot(dir string) (string, error) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNoRoot
+		}
+
+		dir = parent
+	}
+}
+
+// ModulePath returns the module path declared in <root>/go.mod.
+func ModulePath(root string) (string, error) {
+	file, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("%w (%s)", ErrNoModulePath, filepath.Join(root, "go.mod"))
+}
@@ -0,0 +1,83 @@
+package gomod
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindRoot(t *testing.T) {
+	t.Parallel()
+
+	root, err := ioutil.TempDir("", "aoc-gomod-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	nested := filepath.Join(root, "module", "internal", "pkg")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := FindRoot(nested); !errors.Is(err, ErrNoRoot) {
+		t.Errorf("Got %v, expected %v", err, ErrNoRoot)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(root, "module", "go.mod"), []byte("module example.com/aoc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := FindRoot(nested); err != nil || got != filepath.Join(root, "module") {
+		t.Errorf("Got %v (%v), expected %v", got, err, filepath.Join(root, "module"))
+	}
+}
+
+func TestModulePath(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		goMod string
+
+		// outputs
+		expected    string
+		expectedErr error
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		root, err := ioutil.TempDir("", "aoc-gomod-test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(root)
+
+		if err := ioutil.WriteFile(filepath.Join(root, "go.mod"), []byte(cfg.goMod), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ModulePath(root)
+		if !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+
+		if got != cfg.expected {
+			t.Errorf("Got %v, expected %v", got, cfg.expected)
+		}
+	}
+
+	tests := map[string]Test{
+		"ok":               {goMod: "module example.com/aoc\n\ngo 1.15\n", expected: "example.com/aoc"},
+		"ok: quoted":       {goMod: "module \"example.com/aoc\"\n", expected: "example.com/aoc"},
+		"error: no module": {goMod: "go 1.15\n", expectedErr: ErrNoModulePath},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
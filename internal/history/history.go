@@ -0,0 +1,176 @@
+// Package history stores the results of past runs so they can be compared over time.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// DefaultPath is the default location of the history file, relative to the working
+// directory.
+const DefaultPath = ".aoc/history.ndjson"
+
+// Record is the outcome of solving a single part (or a whole day if Part is 0, e.g. when
+// its input couldn't be parsed) in a given run.
+type Record struct {
+	Time       time.Time `json:"time"`
+	Commit     string    `json:"commit,omitempty"`
+	Year       int       `json:"year"`
+	Day        int       `json:"day"`
+	Part       int       `json:"part"`
+	Answer     string    `json:"answer,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS float64   `json:"duration_ms"`
+	InputHash  string    `json:"input_hash,omitempty"`
+}
+
+// Store is a history file holding one JSON record per line, oldest first.
+type Store struct {
+	Path string
+}
+
+// Append adds the given records to the end of the history file, creating it if needed.
+func (s *Store) Append(records ...Record) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory (%w)", err)
+	}
+
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file (%w)", err)
+	}
+
+	// write all records at once so concurrent runs don't interleave partial lines
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, r := range records {
+		if err := encoder.Encode(r); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write history (%w)", err)
+	}
+
+	return file.Close()
+}
+
+// Load returns all records for the given puzzle, oldest first. A missing history file
+// has no records.
+func (s *Store) Load(year, day int) (records []Record, err error) {
+	file, err := os.Open(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open history file (%w)", err)
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("invalid history record on line %d (%w)", line, err)
+		}
+
+		if r.Year == year && r.Day == day {
+			records = append(records, r)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// HashFile returns the hex-encoded SHA-256 hash of the file at path.
+func HashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// GitCommit returns the short hash of the commit checked out in the working directory,
+// suffixed with "-dirty" if there are uncommitted changes. An empty string is returned
+// if it can't be determined, e.g. outside of a git repository.
+func GitCommit(ctx context.Context) string {
+	commit, err := exec.CommandContext(ctx, "git", "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+
+	commit = bytes.TrimSpace(commit)
+
+	status, err := exec.CommandContext(ctx, "git", "status", "--porcelain", "--untracked-files=no").Output()
+	if err == nil && len(bytes.TrimSpace(status)) > 0 {
+		commit = append(commit, "-dirty"...)
+	}
+
+	return string(commit)
+}
+
+// Change describes how a record differs from the previous record for the same part.
+type Change string
+
+const (
+	First         Change = "first"          // no previous record for this part
+	Unchanged     Change = "unchanged"      // same input, same answer
+	AnswerChanged Change = "answer-changed" // same input, different answer (or error)
+	InputChanged  Change = "input-changed"  // different input, answers aren't comparable
+)
+
+// Changes returns the change in each of the given records (oldest first) compared to the
+// previous record for the same part.
+func Changes(records []Record) []Change {
+	changes := make([]Change, len(records))
+	last := map[int]Record{}
+
+	for i, r := range records {
+		previous, ok := last[r.Part]
+		switch {
+		case !ok:
+			changes[i] = First
+		case previous.InputHash != r.InputHash:
+			changes[i] = InputChanged
+		case previous.Answer != r.Answer || previous.Error != r.Error:
+			changes[i] = AnswerChanged
+		default:
+			changes[i] = Unchanged
+		}
+
+		last[r.Part] = r
+	}
+
+	return changes
+}
@@ -0,0 +1,76 @@
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "aoc-history-test")
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	store := &Store{Path: filepath.Join(dir, "nested", "history.ndjson")}
+
+	// a missing file has no records
+	records, err := store.Load(2020, 5)
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	if len(records) != 0 {
+		t.Errorf("Got %v, expected no records", records)
+	}
+
+	now := time.Date(2020, 12, 5, 12, 0, 0, 0, time.UTC)
+	day5 := []Record{
+		{Time: now, Commit: "abc1234", Year: 2020, Day: 5, Part: 1, Answer: "953", DurationMS: 0.5, InputHash: "f00"},
+		{Time: now, Commit: "abc1234", Year: 2020, Day: 5, Part: 2, Error: "not implemented", DurationMS: 0.5, InputHash: "f00"},
+	}
+
+	if err := store.Append(day5...); err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	if err := store.Append(Record{Time: now, Year: 2020, Day: 6, Part: 1, Answer: "6521"}); err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	records, err = store.Load(2020, 5)
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	if diff := cmp.Diff(day5, records); diff != "" {
+		t.Errorf("Unexpected records (-expected +got):\n%s", diff)
+	}
+}
+
+func TestChanges(t *testing.T) {
+	t.Parallel()
+
+	records := []Record{
+		{Part: 1, Answer: "953", InputHash: "f00"},
+		{Part: 2, Answer: "615", InputHash: "f00"},
+		{Part: 1, Answer: "953", InputHash: "f00"},
+		{Part: 2, Answer: "616", InputHash: "f00"},
+		{Part: 2, Error: "solution timed out", InputHash: "f00"},
+		{Part: 1, Answer: "42", InputHash: "ba7"},
+	}
+
+	expected := []Change{First, First, Unchanged, AnswerChanged, AnswerChanged, InputChanged}
+
+	if diff := cmp.Diff(expected, Changes(records)); diff != "" {
+		t.Errorf("Unexpected changes (-expected +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,200 @@
+package input
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNoSession = errors.New("no session token configured")
+)
+
+const (
+	// DefaultBaseURL is the base URL of the Advent of Code website.
+	DefaultBaseURL = "https://adventofcode.com"
+
+	// DefaultMinInterval is the default minimum time between two requests made by a
+	// Fetcher.
+	DefaultMinInterval = 3 * time.Second
+
+	userAgent = "github.com/segwin/adventofcode-2020"
+)
+
+// Fetcher downloads puzzle inputs and caches them on disk.
+type Fetcher interface {
+	// Fetch returns the path to the cached input file for the given puzzle,
+	// downloading it first if it isn't already cached.
+	Fetch(ctx context.Context, year, day int) (path string, err error)
+}
+
+// FetcherConfig holds the settings used by a Fetcher. Zero values are replaced by
+// their defaults.
+type FetcherConfig struct {
+	// BaseURL is the URL inputs are downloaded from, as <BaseURL>/<year>/day/<day>/input.
+	BaseURL string
+
+	// Session is the session token used to authenticate with the server.
+	Session string
+
+	// CacheDir is the directory inputs are cached in, as <CacheDir>/<year>/day<N>/input.
+	CacheDir string
+
+	// Force causes inputs to be downloaded even if they are already cached.
+	Force bool
+
+	// MinInterval is the minimum time to wait between two requests.
+	MinInterval time.Duration
+
+	// Client is the HTTP client used to make requests.
+	Client *http.Client
+}
+
+type fetcher struct {
+	FetcherConfig
+
+	mu          sync.Mutex
+	lastRequest time.Time
+}
+
+func NewFetcher(cfg FetcherConfig) Fetcher {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultBaseURL
+	}
+
+	if cfg.CacheDir == "" {
+		cfg.CacheDir = "inputs"
+	}
+
+	if cfg.MinInterval == 0 {
+		cfg.MinInterval = DefaultMinInterval
+	}
+
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+
+	return &fetcher{FetcherConfig: cfg}
+}
+
+var (
+	defaultFetcher     Fetcher
+	defaultFetcherOnce sync.Once
+)
+
+// Fetch downloads the input for the given puzzle using the default Fetcher, which
+// reads its session token from the AOC_SESSION environment variable and its base
+// URL from AOC_BASE_URL (if set).
+func Fetch(ctx context.Context, year, day int) (path string, err error) {
+	defaultFetcherOnce.Do(func() {
+		defaultFetcher = NewFetcher(FetcherConfig{
+			BaseURL: os.Getenv("AOC_BASE_URL"),
+			Session: os.Getenv("AOC_SESSION"),
+		})
+	})
+
+	return defaultFetcher.Fetch(ctx, year, day)
+}
+
+func (f *fetcher) Fetch(ctx context.Context, year, day int) (path string, err error) {
+	path = filepath.Join(f.CacheDir, strconv.Itoa(year), fmt.Sprintf("day%d", day), "input")
+
+	// placeholder input files are empty, so only consider non-empty files as cached
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 && !f.Force {
+		return path, nil
+	}
+
+	if f.Session == "" {
+		return "", ErrNoSession
+	}
+
+	if err := f.wait(ctx); err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/%d/day/%d/input", strings.TrimSuffix(f.BaseURL, "/"), year, day)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: f.Session})
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download input (%w)", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w (%s)", ErrBadHTTPResponse, resp.Status)
+	}
+
+	if err := writeFileAtomic(path, resp.Body); err != nil {
+		return "", fmt.Errorf("failed to cache input (%w)", err)
+	}
+
+	return path, nil
+}
+
+// wait blocks until enough time has passed since the last request to make a new one.
+func (f *fetcher) wait(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if delay := time.Until(f.lastRequest.Add(f.MinInterval)); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	f.lastRequest = time.Now()
+	return nil
+}
+
+// writeFileAtomic writes the contents of r to path, only replacing any existing file
+// once everything has been written successfully.
+func writeFileAtomic(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".input-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,143 @@
+package input
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		session     string
+		force       bool
+		cached      string // initial contents of the cached input file, if non-empty
+		statusCode  int
+		serverInput string
+
+		// outputs
+		expectedInput    string
+		expectedRequests int32
+		expectedErr      error
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+
+			if r.URL.Path != "/2020/day/5/input" {
+				t.Errorf("Got %v, expected /2020/day/5/input", r.URL.Path)
+			}
+
+			if cookie, err := r.Cookie("session"); err != nil || cookie.Value != cfg.session {
+				t.Errorf("Got %v (%v), expected session=%s", cookie, err, cfg.session)
+			}
+
+			w.WriteHeader(cfg.statusCode)
+			_, _ = w.Write([]byte(cfg.serverInput))
+		}))
+		defer server.Close()
+
+		cacheDir, err := ioutil.TempDir("", "aoc-fetch-test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(cacheDir)
+
+		if cfg.cached != "" {
+			if err := os.MkdirAll(filepath.Join(cacheDir, "2020", "day5"), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := ioutil.WriteFile(filepath.Join(cacheDir, "2020", "day5", "input"), []byte(cfg.cached), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		fetcher := NewFetcher(FetcherConfig{
+			BaseURL:     server.URL,
+			Session:     cfg.session,
+			CacheDir:    cacheDir,
+			Force:       cfg.force,
+			MinInterval: time.Nanosecond,
+		})
+
+		path, err := fetcher.Fetch(context.Background(), 2020, 5)
+		if !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+
+		if got, expected := atomic.LoadInt32(&requests), cfg.expectedRequests; got != expected {
+			t.Errorf("Got %v requests, expected %v", got, expected)
+		}
+
+		if err != nil {
+			return // we're done
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, expected := string(data), cfg.expectedInput; got != expected {
+			t.Errorf("Got %q, expected %q", got, expected)
+		}
+	}
+
+	tests := map[string]Test{
+		"error: no session": {
+			expectedErr: ErrNoSession,
+		},
+
+		"error: non-200 response": {
+			session:          "abc",
+			statusCode:       http.StatusBadRequest,
+			expectedRequests: 1,
+			expectedErr:      ErrBadHTTPResponse,
+		},
+
+		"ok: downloaded": {
+			session:          "abc",
+			statusCode:       http.StatusOK,
+			serverInput:      "FBFBBFFRLR\n",
+			expectedInput:    "FBFBBFFRLR\n",
+			expectedRequests: 1,
+		},
+
+		"ok: already cached": {
+			session:          "abc",
+			cached:           "BFFFBBFRRR\n",
+			statusCode:       http.StatusOK,
+			serverInput:      "FBFBBFFRLR\n",
+			expectedInput:    "BFFFBBFRRR\n",
+			expectedRequests: 0,
+		},
+
+		"ok: already cached, forced": {
+			session:          "abc",
+			force:            true,
+			cached:           "BFFFBBFRRR\n",
+			statusCode:       http.StatusOK,
+			serverInput:      "FBFBBFFRLR\n",
+			expectedInput:    "FBFBBFFRLR\n",
+			expectedRequests: 1,
+		},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
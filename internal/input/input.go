@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"strings"
 )
@@ -37,27 +39,95 @@ type scanner struct {
 	close func() error
 }
 
+func newScanner(ctx context.Context, r io.Reader, close func() error) *scanner {
+	return &scanner{
+		Scanner: bufio.NewScanner(&contextReader{ctx: ctx, r: r}),
+		close:   close,
+	}
+}
+
 func (s *scanner) Close() error { return s.close() }
 
-func NewFileScanner(ctx context.Context, path string) (Scanner, error) {
+// contextReader is an io.Reader that stops reading once its context is done,
+// returning the context's error instead.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c *contextReader) Read(p []byte) (n int, err error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return c.r.Read(p)
+}
+
+// Stdin is the path used to designate standard input.
+const Stdin = "-"
+
+// Open opens the input file at the given path for reading. If path is Stdin, standard
+// input is returned instead and closing it is a no-op.
+func Open(path string) (io.ReadCloser, error) {
+	if path == Stdin {
+		return ioutil.NopCloser(os.Stdin), nil
+	}
+
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file (%w)", err)
 	}
 
-	scanner := &scanner{
-		Scanner: bufio.NewScanner(file),
-		close:   file.Close,
+	return file, nil
+}
+
+// NewFileScanner returns a Scanner reading lines from the file at path, which may be
+// Stdin to read from standard input. Reads fail with the context's error once ctx is
+// done.
+func NewFileScanner(ctx context.Context, path string) (Scanner, error) {
+	file, err := Open(path)
+	if err != nil {
+		return nil, err
 	}
 
-	return scanner, nil
+	return newScanner(ctx, file, file.Close), nil
+}
+
+// NewReaderScanner returns a Scanner reading lines from r. Closing the scanner does
+// not close r: the caller remains responsible for it. Reads fail with the context's
+// error once ctx is done.
+func NewReaderScanner(ctx context.Context, r io.Reader) Scanner {
+	return newScanner(ctx, r, func() error { return nil })
 }
 
 func NewStringScanner(ctx context.Context, input string) Scanner {
-	scanner := &scanner{
-		Scanner: bufio.NewScanner(strings.NewReader(input)),
-		close:   func() error { return nil },
+	return NewReaderScanner(ctx, strings.NewReader(input))
+}
+
+// LineError is an error found while parsing a given line of the input, numbered from 1.
+// Parsers reading the whole input before parsing it should return one, so that the line
+// can still be reported.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("%v (line %d)", e.Err, e.Line)
+}
+
+func (e *LineError) Unwrap() error { return e.Err }
+
+// ReadLines reads all remaining lines from the given scanner, trimming any leading
+// or trailing whitespace from each one.
+func ReadLines(scanner Scanner) (lines []string, err error) {
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSpace(scanner.Text()))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
 	}
 
-	return scanner
+	return lines, nil
 }
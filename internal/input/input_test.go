@@ -0,0 +1,79 @@
+package input
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadLines(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		input    string
+		canceled bool
+
+		// outputs
+		expected    []string
+		expectedErr error
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		if cfg.canceled {
+			cancel()
+		}
+
+		scanner := NewReaderScanner(ctx, strings.NewReader(cfg.input))
+		defer scanner.Close()
+
+		got, err := ReadLines(scanner)
+		if !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+
+		if diff := cmp.Diff(cfg.expected, got); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+	}
+
+	tests := map[string]Test{
+		"ok: empty input": {
+			input:    "",
+			expected: nil,
+		},
+
+		"ok: trailing newline": {
+			input:    "abc\ndef\n",
+			expected: []string{"abc", "def"},
+		},
+
+		"ok: no trailing newline": {
+			input:    "abc\ndef",
+			expected: []string{"abc", "def"},
+		},
+
+		"ok: blank lines & surrounding whitespace": {
+			input:    "  abc \r\n\r\ndef\t\n",
+			expected: []string{"abc", "", "def"},
+		},
+
+		"error: context canceled": {
+			input:       "abc\ndef\n",
+			canceled:    true,
+			expectedErr: context.Canceled,
+		},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
@@ -0,0 +1,179 @@
+// Package lint validates puzzle input files before they are solved.
+package lint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+var (
+	ErrParserPanicked = errors.New("parser panicked")
+)
+
+// Kind identifies the problem reported by an Issue.
+type Kind string
+
+const (
+	Missing            Kind = "missing"
+	Empty              Kind = "empty"
+	CRLF               Kind = "crlf"
+	TrailingWhitespace Kind = "trailing-whitespace"
+	Unparseable        Kind = "unparseable"
+)
+
+// Issue is a problem found in an input file.
+type Issue struct {
+	Path string
+	Kind Kind
+
+	// Line is the line number the issue was found on, starting at 1. It is 0 if the
+	// issue applies to the file as a whole.
+	Line int
+
+	Message string
+}
+
+func (i Issue) String() string {
+	if i.Line == 0 {
+		return fmt.Sprintf("%s: %s: %s", i.Path, i.Kind, i.Message)
+	}
+
+	return fmt.Sprintf("%s:%d: %s: %s", i.Path, i.Line, i.Kind, i.Message)
+}
+
+// File checks the input file at path, then parses it with the given solution without
+// solving any of its parts. All issues found are returned. An error is only returned if
+// the file exists but couldn't be read.
+func File(ctx context.Context, solution solutions.Solution, path string) (issues []Issue, err error) {
+	data, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Issue{{Path: path, Kind: Missing, Message: "file not found"}}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read input (%w)", err)
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return []Issue{{Path: path, Kind: Empty, Message: "file has no content"}}, nil
+	}
+
+	issues = checkLines(path, data)
+
+	if issue := checkParse(ctx, solution, path, data); issue != nil {
+		issues = append(issues, *issue)
+	}
+
+	return issues, nil
+}
+
+// checkLines reports CRLF line endings (once per file) and trailing whitespace (once
+// per line) in data.
+func checkLines(path string, data []byte) (issues []Issue) {
+	crlfLines, firstCRLF := 0, 0
+
+	for i, text := range bytes.Split(data, []byte("\n")) {
+		line := i + 1
+
+		if bytes.HasSuffix(text, []byte("\r")) {
+			crlfLines++
+			if firstCRLF == 0 {
+				firstCRLF = line
+			}
+
+			text = text[:len(text)-1]
+		}
+
+		if trimmed := bytes.TrimRight(text, " \t"); len(trimmed) < len(text) {
+			issues = append(issues, Issue{Path: path, Kind: TrailingWhitespace, Line: line, Message: fmt.Sprintf("%d trailing whitespace characters", len(text)-len(trimmed))})
+		}
+	}
+
+	if crlfLines > 0 {
+		crlf := Issue{Path: path, Kind: CRLF, Line: firstCRLF, Message: fmt.Sprintf("%d CRLF-terminated lines, expected LF", crlfLines)}
+		issues = append([]Issue{crlf}, issues...)
+	}
+
+	return issues
+}
+
+// checkParse parses data with the given solution, reporting the line it stopped at if it
+// fails or panics. If the parser returns an input.LineError, its line is reported instead,
+// as parsers that read the whole input up front always stop at its end.
+func checkParse(ctx context.Context, solution solutions.Solution, path string, data []byte) (issue *Issue) {
+	r := &lineReader{r: bytes.NewReader(data)}
+
+	defer func() {
+		if p := recover(); p != nil {
+			issue = &Issue{Path: path, Kind: Unparseable, Line: r.Line(), Message: fmt.Sprintf("%v (%v)", ErrParserPanicked, p)}
+		}
+	}()
+
+	if _, err := solution.Parse(ctx, r); err != nil {
+		line := r.Line()
+
+		var lineErr *input.LineError
+		if errors.As(err, &lineErr) {
+			line = lineErr.Line
+		}
+
+		return &Issue{Path: path, Kind: Unparseable, Line: line, Message: err.Error()}
+	}
+
+	return nil
+}
+
+// lineReader is an io.Reader returning at most one line per call to Read. As a line
+// scanner never reads past the line it is about to return, the number of lines read so
+// far is the line the consumer is working on.
+type lineReader struct {
+	r     *bytes.Reader
+	lines int
+
+	unterminated bool // the last line has no trailing newline
+	eofReads     int  // number of calls to Read that returned io.EOF
+}
+
+func (l *lineReader) Read(p []byte) (n int, err error) {
+	for n < len(p) {
+		b, err := l.r.ReadByte()
+		if err == io.EOF {
+			if n == 0 {
+				l.eofReads++
+				return 0, io.EOF
+			}
+
+			// the last line has no newline, but it's a line all the same
+			l.unterminated = true
+			l.lines++
+			break
+		}
+
+		p[n] = b
+		n++
+
+		if b == '\n' {
+			l.lines++
+			break
+		}
+	}
+
+	return n, nil
+}
+
+// Line returns the line being processed by the consumer, or 0 if it already read past
+// the last line. A line scanner must see io.EOF to return an unterminated last line, so
+// the consumer is only past it once it reads again.
+func (l *lineReader) Line() int {
+	if l.eofReads > 1 || (l.eofReads == 1 && !l.unterminated) {
+		return 0
+	}
+
+	return l.lines
+}
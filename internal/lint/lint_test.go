@@ -0,0 +1,178 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+// intsSolution parses one integer per line, panicking on negative values.
+type intsSolution struct{}
+
+func (s *intsSolution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
+
+	for scanner.Scan() {
+		value, err := strconv.Atoi(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+
+		if value < 0 {
+			panic("negative value")
+		}
+	}
+
+	return nil, scanner.Err()
+}
+
+// linesSolution reads the whole input up front, then parses one integer per line.
+type linesSolution struct{}
+
+func (s *linesSolution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
+
+	lines, err := input.ReadLines(scanner)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, line := range lines {
+		if _, err := strconv.Atoi(line); err != nil {
+			return nil, fmt.Errorf("failed to parse input (%w)", &input.LineError{Line: i + 1, Err: err})
+		}
+	}
+
+	return nil, nil
+}
+
+// wholeSolution reads the whole input up front, then rejects it without saying where.
+type wholeSolution struct{}
+
+func (s *wholeSolution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	if _, err := ioutil.ReadAll(r); err != nil {
+		return nil, err
+	}
+
+	return nil, errors.New("no solution")
+}
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		contents *string            // nil if the file is missing
+		solution solutions.Solution // intsSolution if nil
+
+		// outputs
+		expectedIssues []Issue
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		dir, err := ioutil.TempDir("", "aoc-lint-test")
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "input")
+		if cfg.contents != nil {
+			if err := ioutil.WriteFile(path, []byte(*cfg.contents), 0644); err != nil {
+				t.Fatalf("Got %v, expected nil", err)
+			}
+		}
+
+		solution := cfg.solution
+		if solution == nil {
+			solution = &intsSolution{}
+		}
+
+		issues, err := File(context.Background(), solution, path)
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		for i := range cfg.expectedIssues {
+			cfg.expectedIssues[i].Path = path
+		}
+
+		// only compare kinds & positions, messages are for humans
+		for i := range issues {
+			issues[i].Message = ""
+		}
+
+		if diff := cmp.Diff(cfg.expectedIssues, issues); diff != "" {
+			t.Errorf("Unexpected issues (-expected +got):\n%s", diff)
+		}
+	}
+
+	str := func(s string) *string { return &s }
+
+	tests := map[string]Test{
+		"ok": {
+			contents: str("1\n2\n3\n"),
+		},
+		"missing": {
+			expectedIssues: []Issue{{Kind: Missing}},
+		},
+		"empty": {
+			contents:       str("\n\n"),
+			expectedIssues: []Issue{{Kind: Empty}},
+		},
+		"crlf": {
+			contents:       str("1\n2\r\n3\r\n"),
+			expectedIssues: []Issue{{Kind: CRLF, Line: 2}},
+		},
+		"trailing whitespace": {
+			contents: str("1\n2 \n3\t\n"),
+			expectedIssues: []Issue{
+				{Kind: TrailingWhitespace, Line: 2},
+				{Kind: TrailingWhitespace, Line: 3},
+				{Kind: Unparseable, Line: 2},
+			},
+		},
+		"unparseable": {
+			contents:       str("1\n2\nthree\n4\n"),
+			expectedIssues: []Issue{{Kind: Unparseable, Line: 3}},
+		},
+		"unparseable: no trailing newline": {
+			contents:       str("1\n2\nthree"),
+			expectedIssues: []Issue{{Kind: Unparseable, Line: 3}},
+		},
+		"unparseable: whole input": {
+			contents:       str("1\n2\n3\n"),
+			solution:       &wholeSolution{},
+			expectedIssues: []Issue{{Kind: Unparseable}},
+		},
+		"unparseable: line error": {
+			contents:       str("1\n2\nthree\n4\n"),
+			solution:       &linesSolution{},
+			expectedIssues: []Issue{{Kind: Unparseable, Line: 3}},
+		},
+		"parser panic": {
+			contents:       str("1\n-2\n3\n"),
+			expectedIssues: []Issue{{Kind: Unparseable, Line: 2}},
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
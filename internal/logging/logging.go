@@ -0,0 +1,135 @@
+// Package logging provides a leveled logger carried through contexts, so solutions can
+// report debug traces & warnings without mixing them up with their answers.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+)
+
+// Level is the severity of a log message.
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+
+	// off is above every level, so nothing is logged.
+	off
+)
+
+func (l Level) String() string {
+	switch l {
+	case Debug:
+		return "DEBUG"
+	case Info:
+		return "INFO"
+	case Warn:
+		return "WARN"
+	case Error:
+		return "ERROR"
+	}
+
+	return "<unknown>"
+}
+
+// output is shared by a logger & all loggers derived from it.
+type output struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level Level
+}
+
+// Logger writes messages at or above a given level to an io.Writer, one per line. It is
+// safe for concurrent use.
+type Logger struct {
+	out    *output
+	prefix string
+}
+
+// New returns a logger writing messages at or above the given level to w.
+func New(w io.Writer, level Level) *Logger {
+	return &Logger{out: &output{w: w, level: level}}
+}
+
+// Discard returns a logger that doesn't log anything.
+func Discard() *Logger {
+	return New(ioutil.Discard, off)
+}
+
+// With returns a logger writing to the same output as this one, adding the given prefix
+// to each message (e.g. to identify concurrent tasks).
+func (l *Logger) With(prefix string) *Logger {
+	if l.prefix != "" {
+		prefix = l.prefix + " " + prefix
+	}
+
+	return &Logger{out: l.out, prefix: prefix}
+}
+
+// SetLevel changes the minimum level of the messages logged by this logger & all loggers
+// sharing its output.
+func (l *Logger) SetLevel(level Level) {
+	l.out.mu.Lock()
+	defer l.out.mu.Unlock()
+
+	l.out.level = level
+}
+
+// Enabled returns true if messages at the given level are logged. It is useful to avoid
+// computing expensive debug traces that would be discarded anyway.
+func (l *Logger) Enabled(level Level) bool {
+	l.out.mu.Lock()
+	defer l.out.mu.Unlock()
+
+	return level >= l.out.level
+}
+
+func (l *Logger) Debugf(format string, args ...interface{}) { l.logf(Debug, format, args...) }
+func (l *Logger) Infof(format string, args ...interface{})  { l.logf(Info, format, args...) }
+func (l *Logger) Warnf(format string, args ...interface{})  { l.logf(Warn, format, args...) }
+func (l *Logger) Errorf(format string, args ...interface{}) { l.logf(Error, format, args...) }
+
+func (l *Logger) logf(level Level, format string, args ...interface{}) {
+	l.out.mu.Lock()
+	defer l.out.mu.Unlock()
+
+	if level < l.out.level {
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(level.String())
+	if l.prefix != "" {
+		fmt.Fprintf(&b, " [%s]", l.prefix)
+	}
+
+	b.WriteString(" ")
+	fmt.Fprintf(&b, format, args...)
+	b.WriteString("\n")
+
+	_, _ = io.WriteString(l.out.w, b.String()) // nowhere left to report a failure to log
+}
+
+type contextKey struct{}
+
+// WithLogger returns a copy of ctx carrying the given logger.
+func WithLogger(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or a logger discarding all messages if
+// there is none.
+func FromContext(ctx context.Context) *Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*Logger); ok {
+		return logger
+	}
+
+	return Discard()
+}
@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		level Level
+
+		// outputs
+		expected string
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		logger := New(&buf, cfg.level)
+
+		logger.Debugf("debug %d", 1)
+		logger.With("2020 day 5").Infof("info")
+		logger.With("2020").With("day 6").Warnf("warning")
+		logger.Errorf("error: %v", "boom")
+
+		if diff := cmp.Diff(cfg.expected, buf.String()); diff != "" {
+			t.Errorf("Unexpected output (-expected +got):\n%s", diff)
+		}
+	}
+
+	tests := map[string]Test{
+		"debug": {
+			level:    Debug,
+			expected: "DEBUG debug 1\nINFO [2020 day 5] info\nWARN [2020 day 6] warning\nERROR error: boom\n",
+		},
+		"info": {
+			level:    Info,
+			expected: "INFO [2020 day 5] info\nWARN [2020 day 6] warning\nERROR error: boom\n",
+		},
+		"error": {
+			level:    Error,
+			expected: "ERROR error: boom\n",
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := New(&buf, Info)
+	ctx := WithLogger(context.Background(), logger)
+
+	// derived loggers share their level with their parent
+	FromContext(ctx).With("day 1").Debugf("hidden")
+	logger.SetLevel(Debug)
+	FromContext(ctx).With("day 1").Debugf("shown")
+
+	if expected := "DEBUG [day 1] shown\n"; buf.String() != expected {
+		t.Errorf("Got %q, expected %q", buf.String(), expected)
+	}
+
+	if FromContext(context.Background()).Enabled(Error) {
+		t.Errorf("Got enabled logger, expected one discarding everything")
+	}
+}
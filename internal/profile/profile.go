@@ -0,0 +1,152 @@
+// Package profile captures CPU & heap profiles and execution traces of the code run
+// between two points, e.g. while solving a single day or part.
+package profile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
+)
+
+// Config holds the paths of the files to write each kind of profile to. Kinds with an
+// empty path aren't captured.
+type Config struct {
+	// CPU is the path of the CPU profile, in pprof format.
+	CPU string
+
+	// Mem is the path of the heap profile, in pprof format. It is captured when the
+	// profiled code is done.
+	Mem string
+
+	// Trace is the path of the execution trace, for use with "go tool trace".
+	Trace string
+}
+
+// Enabled returns true if at least one kind of profile is captured.
+func (c Config) Enabled() bool {
+	return c.CPU != "" || c.Mem != "" || c.Trace != ""
+}
+
+// Session is a profile being captured.
+type Session struct {
+	mem   string
+	cpu   *os.File
+	trace *os.File
+}
+
+// Start starts capturing the configured profiles. If label is non-empty, it is added to
+// each file's name before its extension (e.g. "cpu.pprof" becomes "cpu.day5.pprof"), so
+// that several sessions can be captured one after the other. Only one session may be
+// active at a time.
+func (c Config) Start(label string) (*Session, error) {
+	s := &Session{mem: Path(c.Mem, label)}
+
+	if c.CPU != "" {
+		file, err := create(Path(c.CPU, label))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to start CPU profile (%w)", err)
+		}
+
+		s.cpu = file
+	}
+
+	if c.Trace != "" {
+		file, err := create(Path(c.Trace, label))
+		if err != nil {
+			s.stop()
+			return nil, err
+		}
+
+		if err := trace.Start(file); err != nil {
+			file.Close()
+			s.stop()
+			return nil, fmt.Errorf("failed to start trace (%w)", err)
+		}
+
+		s.trace = file
+	}
+
+	return s, nil
+}
+
+// Stop stops capturing the session's profiles & writes them to their files.
+func (s *Session) Stop() error {
+	if err := s.stop(); err != nil {
+		return err
+	}
+
+	if s.mem != "" {
+		return writeHeapProfile(s.mem)
+	}
+
+	return nil
+}
+
+// stop stops the CPU profile & trace, if started.
+func (s *Session) stop() (err error) {
+	if s.trace != nil {
+		trace.Stop()
+		if closeErr := s.trace.Close(); closeErr != nil {
+			err = fmt.Errorf("failed to write trace (%w)", closeErr)
+		}
+	}
+
+	if s.cpu != nil {
+		pprof.StopCPUProfile()
+		if closeErr := s.cpu.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to write CPU profile (%w)", closeErr)
+		}
+	}
+
+	s.cpu, s.trace = nil, nil
+	return err
+}
+
+// Path returns the given path with label added before its extension, or path itself if
+// either is empty.
+func Path(path, label string) string {
+	if path == "" || label == "" {
+		return path
+	}
+
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + label + ext
+}
+
+func writeHeapProfile(path string) error {
+	file, err := create(path)
+	if err != nil {
+		return err
+	}
+
+	runtime.GC() // only include live objects in the in-use figures
+
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write heap profile (%w)", err)
+	}
+
+	return file.Close()
+}
+
+func create(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create profile directory (%w)", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create profile (%w)", err)
+	}
+
+	return file, nil
+}
@@ -0,0 +1,73 @@
+package profile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		path  string
+		label string
+
+		// outputs
+		expected string
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		if got := Path(cfg.path, cfg.label); got != cfg.expected {
+			t.Errorf("Got %v, expected %v", got, cfg.expected)
+		}
+	}
+
+	tests := map[string]Test{
+		"label":        {path: "out/cpu.pprof", label: "day5.part1", expected: "out/cpu.day5.part1.pprof"},
+		"no extension": {path: "trace", label: "day5", expected: "trace.day5"},
+		"no label":     {path: "cpu.pprof", expected: "cpu.pprof"},
+		"no path":      {label: "day5", expected: ""},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+// not parallel: only one CPU profile & trace can be captured at a time
+func TestSession(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aoc-profile-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg := Config{
+		CPU:   filepath.Join(dir, "cpu.pprof"),
+		Mem:   filepath.Join(dir, "mem.pprof"),
+		Trace: filepath.Join(dir, "trace.out"),
+	}
+
+	for _, label := range []string{"part1", "part2"} {
+		session, err := cfg.Start(label)
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		if err := session.Stop(); err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+	}
+
+	for _, name := range []string{"cpu.part1.pprof", "mem.part1.pprof", "trace.part1.out", "cpu.part2.pprof", "mem.part2.pprof", "trace.part2.out"} {
+		if info, err := os.Stat(filepath.Join(dir, name)); err != nil || info.Size() == 0 {
+			t.Errorf("Got %v (%v), expected non-empty %s", info, err, name)
+		}
+	}
+}
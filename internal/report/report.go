@@ -0,0 +1,229 @@
+// Package report renders the results of a full run as a Markdown or HTML document.
+package report
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown report format")
+)
+
+const (
+	Markdown = "md"
+	HTML     = "html"
+)
+
+var (
+	Formats = []string{Markdown, HTML}
+)
+
+// Status is the outcome of a part (or a whole day if it couldn't be solved).
+type Status string
+
+const (
+	Pass       Status = "PASS"       // the answer matches the expected one
+	Fail       Status = "FAIL"       // the answer doesn't match the expected one
+	Error      Status = "ERROR"      // no answer could be computed
+	Skipped    Status = "SKIPPED"    // the part isn't implemented yet
+	Unverified Status = "UNVERIFIED" // an answer was computed, but there is none to compare it to
+)
+
+// Report holds the results of solving every day of an event.
+type Report struct {
+	Year      int
+	Generated time.Time
+	Days      []Day
+}
+
+// Day holds the results of solving a single day.
+type Day struct {
+	Day   int
+	Title string
+
+	// Link is the path or URL to the day's solution package.
+	Link string
+
+	Duration time.Duration
+
+	// Err is set if the day couldn't be solved at all, in which case it has no parts.
+	Err string
+
+	Parts []Part
+}
+
+// Part holds the result of solving a single part of a day.
+type Part struct {
+	Number   int
+	Answer   string
+	Expected string
+	Err      string
+
+	// Duration is the time taken to solve this part, excluding parsing.
+	Duration time.Duration
+
+	// Skipped is set if the part isn't implemented yet, in which case Err explains why.
+	Skipped bool
+}
+
+// Status returns the status of this part, comparing its answer to the expected one.
+func (p Part) Status() Status {
+	switch {
+	case p.Skipped:
+		return Skipped
+	case p.Err != "":
+		return Error
+	case p.Expected == "":
+		return Unverified
+	case p.Answer != p.Expected:
+		return Fail
+	}
+
+	return Pass
+}
+
+// Summary counts the parts with each status in a report.
+type Summary struct {
+	Passed     int
+	Failed     int
+	Errors     int
+	Skipped    int
+	Unverified int
+}
+
+// Summary returns the number of parts with each status in the report. Days that couldn't
+// be solved count as a single error.
+func (r Report) Summary() (summary Summary) {
+	count := func(status Status) {
+		switch status {
+		case Pass:
+			summary.Passed++
+		case Fail:
+			summary.Failed++
+		case Error:
+			summary.Errors++
+		case Skipped:
+			summary.Skipped++
+		case Unverified:
+			summary.Unverified++
+		}
+	}
+
+	for _, day := range r.Days {
+		if day.Err != "" {
+			count(Error)
+			continue
+		}
+
+		for _, part := range day.Parts {
+			count(part.Status())
+		}
+	}
+
+	return summary
+}
+
+// TotalDuration returns the time taken to solve all days, back-to-back.
+func (r Report) TotalDuration() (total time.Duration) {
+	for _, day := range r.Days {
+		total += day.Duration
+	}
+
+	return total
+}
+
+// CheckFormat returns an error wrapping ErrUnknownFormat if format isn't one of Formats.
+func CheckFormat(format string) error {
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w (%q, expected one of %v)", ErrUnknownFormat, format, Formats)
+}
+
+// Render writes the report to w in the given format.
+func Render(w io.Writer, format string, r Report) error {
+	switch format {
+	case Markdown:
+		return markdownTemplate.Execute(w, r)
+	case HTML:
+		return htmlTemplate.Execute(w, r)
+	}
+
+	return CheckFormat(format)
+}
+
+var funcs = map[string]interface{}{
+	"duration": func(d time.Duration) string { return d.Round(time.Microsecond).String() },
+	"date":     func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 MST") },
+	"cell":     markdownCell,
+	"lower":    func(s Status) string { return strings.ToLower(string(s)) },
+}
+
+// markdownCell escapes s for use in a Markdown table cell.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+var markdownTemplate = template.Must(template.New("md").Funcs(funcs).Parse(`## Results ({{.Year}})
+
+| Day | Part | Answer | Time | Status |
+| --- | ---: | --- | ---: | --- |
+{{- range .Days}}
+{{- $day := .}}
+{{- if .Err}}
+| [{{.Day}}: {{cell .Title}}]({{.Link}}) | - | {{cell .Err}} | {{duration .Duration}} | ERROR |
+{{- else}}
+{{- range .Parts}}
+| [{{$day.Day}}: {{cell $day.Title}}]({{$day.Link}}) | {{.Number}} | {{if .Err}}{{cell .Err}}{{else}}` + "`{{cell .Answer}}`" + `{{end}}{{if eq .Status "FAIL"}} (expected ` + "`{{cell .Expected}}`" + `){{end}} | {{duration .Duration}} | {{.Status}} |
+{{- end}}
+{{- end}}
+{{- end}}
+{{with .Summary}}
+{{.Passed}} passed, {{.Failed}} failed, {{.Errors}} errors, {{.Skipped}} skipped & {{.Unverified}} unverified in {{duration $.TotalDuration}} (generated {{date $.Generated}}).
+{{- end}}
+`))
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Advent of Code {{.Year}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.25em 0.75em; text-align: left; }
+.pass { color: #2a7d2a; }
+.fail, .error { color: #c0392b; font-weight: bold; }
+.skipped, .unverified { color: #888; }
+</style>
+</head>
+<body>
+<h1>Advent of Code {{.Year}}</h1>
+<table>
+<tr><th>Day</th><th>Part</th><th>Answer</th><th>Time</th><th>Status</th></tr>
+{{- range .Days}}
+{{- $day := .}}
+{{- if .Err}}
+<tr><td><a href="{{.Link}}">{{.Day}}: {{.Title}}</a></td><td>-</td><td>{{.Err}}</td><td>{{duration .Duration}}</td><td class="error">ERROR</td></tr>
+{{- else}}
+{{- range .Parts}}
+<tr><td><a href="{{$day.Link}}">{{$day.Day}}: {{$day.Title}}</a></td><td>{{.Number}}</td><td>{{if .Err}}{{.Err}}{{else}}<code>{{.Answer}}</code>{{end}}{{if eq .Status "FAIL"}} (expected <code>{{.Expected}}</code>){{end}}</td><td>{{duration .Duration}}</td><td class="{{lower .Status}}">{{.Status}}</td></tr>
+{{- end}}
+{{- end}}
+{{- end}}
+</table>
+{{- with .Summary}}
+<p>{{.Passed}} passed, {{.Failed}} failed, {{.Errors}} errors, {{.Skipped}} skipped &amp; {{.Unverified}} unverified in {{duration $.TotalDuration}} (generated {{date $.Generated}}).</p>
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,127 @@
+package report
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		format string
+
+		// outputs
+		expectedLines []string // lines expected somewhere in the output
+		expectedErr   error
+	}
+
+	r := Report{
+		Year:      2020,
+		Generated: time.Date(2020, 12, 25, 6, 0, 0, 0, time.UTC),
+		Days: []Day{
+			{
+				Day:      5,
+				Title:    "Binary Boarding",
+				Link:     "internal/solutions/2020/day5",
+				Duration: 1500 * time.Microsecond,
+				Parts: []Part{
+					{Number: 1, Answer: "953", Expected: "953", Duration: 500 * time.Microsecond},
+					{Number: 2, Answer: "616", Expected: "615", Duration: 700 * time.Microsecond},
+				},
+			},
+			{
+				Day:      6,
+				Title:    "Custom | Customs",
+				Link:     "internal/solutions/2020/day6",
+				Duration: time.Millisecond,
+				Err:      "failed to open file",
+			},
+			{
+				Day:   17,
+				Title: "Conway Cubes",
+				Link:  "internal/solutions/2020/day17",
+				Parts: []Part{
+					{Number: 1, Answer: "112"},
+					{Number: 2, Err: "not implemented", Skipped: true},
+				},
+			},
+		},
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		err := Render(&buf, cfg.format, r)
+		if !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+
+		lines := strings.Split(buf.String(), "\n")
+		for _, expected := range cfg.expectedLines {
+			found := false
+			for _, line := range lines {
+				found = found || line == expected
+			}
+
+			if !found {
+				t.Errorf("Expected line %q in output:\n%s", expected, buf.String())
+			}
+		}
+	}
+
+	tests := map[string]Test{
+		"markdown": {
+			format: Markdown,
+			expectedLines: []string{
+				"| [5: Binary Boarding](internal/solutions/2020/day5) | 1 | `953` | 500µs | PASS |",
+				"| [5: Binary Boarding](internal/solutions/2020/day5) | 2 | `616` (expected `615`) | 700µs | FAIL |",
+				`| [6: Custom \| Customs](internal/solutions/2020/day6) | - | failed to open file | 1ms | ERROR |`,
+				"| [17: Conway Cubes](internal/solutions/2020/day17) | 1 | `112` | 0s | UNVERIFIED |",
+				"| [17: Conway Cubes](internal/solutions/2020/day17) | 2 | not implemented | 0s | SKIPPED |",
+				"1 passed, 1 failed, 1 errors, 1 skipped & 1 unverified in 2.5ms (generated 2020-12-25 06:00 UTC).",
+			},
+		},
+		"html": {
+			format: HTML,
+			expectedLines: []string{
+				`<tr><td><a href="internal/solutions/2020/day5">5: Binary Boarding</a></td><td>2</td><td><code>616</code> (expected <code>615</code>)</td><td>700µs</td><td class="fail">FAIL</td></tr>`,
+				`<tr><td><a href="internal/solutions/2020/day17">17: Conway Cubes</a></td><td>2</td><td>not implemented</td><td>0s</td><td class="skipped">SKIPPED</td></tr>`,
+				`<p>1 passed, 1 failed, 1 errors, 1 skipped &amp; 1 unverified in 2.5ms (generated 2020-12-25 06:00 UTC).</p>`,
+			},
+		},
+		"unknown format": {
+			format:      "pdf",
+			expectedErr: ErrUnknownFormat,
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+func TestReportSummary(t *testing.T) {
+	t.Parallel()
+
+	r := Report{
+		Days: []Day{
+			{Parts: []Part{{Answer: "1", Expected: "1"}, {Answer: "2", Expected: "3"}}},
+			{Err: "boom"},
+			{Parts: []Part{{Answer: "4"}, {Err: "not implemented", Skipped: true}, {Err: "no solution"}}},
+		},
+	}
+
+	expected := Summary{Passed: 1, Failed: 1, Errors: 2, Skipped: 1, Unverified: 1}
+	if diff := cmp.Diff(expected, r.Summary()); diff != "" {
+		t.Errorf("Unexpected summary (-expected +got):\n%s", diff)
+	}
+}
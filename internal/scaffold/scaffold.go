@@ -0,0 +1,181 @@
+// Package scaffold generates the boilerplate for new solution packages.
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/segwin/adventofcode-2020/internal/gomod"
+)
+
+var (
+	ErrInvalidDay    = errors.New("invalid day")
+	ErrInvalidYear   = errors.New("invalid year")
+	ErrPackageExists = errors.New("a package that isn't a stub already exists for this day")
+)
+
+// stubMarker is only found in the source of packages that are still stubs.
+const stubMarker = "solutions.StubTag"
+
+// Config describes the day package to generate.
+type Config struct {
+	// Root is the root directory of the module.
+	Root string
+
+	Year  int
+	Day   int
+	Title string
+}
+
+// Generate creates the package for the given day in <Root>/internal/solutions/<year>/day<N>
+// along with placeholder input files in <Root>/inputs/<year>/day<N>, then registers the
+// package in <Root>/internal/solutions/all. An existing stub package is replaced, but
+// ErrPackageExists is returned if the existing package isn't a stub. The paths of all
+// written files are returned.
+func Generate(cfg Config) (written []string, err error) {
+	if cfg.Day < 1 || cfg.Day > 25 {
+		return nil, fmt.Errorf("%w (%d)", ErrInvalidDay, cfg.Day)
+	}
+
+	if cfg.Year < 2015 {
+		return nil, fmt.Errorf("%w (%d)", ErrInvalidYear, cfg.Year)
+	}
+
+	if cfg.Title == "" {
+		cfg.Title = fmt.Sprintf("Day %d", cfg.Day)
+	}
+
+	module, err := gomod.ModulePath(cfg.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	dayDir := filepath.Join(cfg.Root, "internal", "solutions", strconv.Itoa(cfg.Year), fmt.Sprintf("day%d", cfg.Day))
+	if exists, stub, err := isStub(dayDir); err != nil {
+		return nil, err
+	} else if exists && !stub {
+		return nil, fmt.Errorf("%w (%s)", ErrPackageExists, dayDir)
+	}
+
+	if err := os.MkdirAll(dayDir, 0755); err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Module string
+		Year   int
+		Day    int
+		Title  string
+	}{module, cfg.Year, cfg.Day, cfg.Title}
+
+	names := make([]string, 0, len(dayTemplates))
+	for name := range dayTemplates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		var buf bytes.Buffer
+		if err := dayTemplates[name].Execute(&buf, data); err != nil {
+			return nil, err
+		}
+
+		filePath := filepath.Join(dayDir, name)
+		if err := writeSource(filePath, buf.Bytes()); err != nil {
+			return nil, err
+		}
+
+		written = append(written, filePath)
+	}
+
+	// create empty placeholders for the input & example, never overwriting files that
+	// may already have been filled in
+	inputDir := filepath.Join(cfg.Root, "inputs", strconv.Itoa(cfg.Year), fmt.Sprintf("day%d", cfg.Day))
+	if err := os.MkdirAll(inputDir, 0755); err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{"input", "example1", "example1.expected"} {
+		filePath := filepath.Join(inputDir, name)
+		if _, err := os.Stat(filePath); !errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err := ioutil.WriteFile(filePath, nil, 0644); err != nil {
+			return nil, err
+		}
+
+		written = append(written, filePath)
+	}
+
+	allPath, err := writeRegistrations(cfg.Root, module)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register package (%w)", err)
+	}
+
+	return append(written, allPath), nil
+}
+
+// isStub reports whether a package exists in dir and if so, whether it is a stub.
+func isStub(dir string) (exists, stub bool, err error) {
+	source, err := ioutil.ReadFile(filepath.Join(dir, "solution.go"))
+	if errors.Is(err, os.ErrNotExist) {
+		// consider any other Go file as a sign of an existing package
+		matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		return len(matches) > 0, false, err
+	} else if err != nil {
+		return false, false, err
+	}
+
+	return true, bytes.Contains(source, []byte(stubMarker)), nil
+}
+
+// writeRegistrations regenerates the all package so that it imports every day package
+// found under <root>/internal/solutions, returning the path of the written file.
+func writeRegistrations(root, module string) (filePath string, err error) {
+	dirs, err := filepath.Glob(filepath.Join(root, "internal", "solutions", "*", "day*"))
+	if err != nil {
+		return "", err
+	}
+
+	var imports []string
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return "", err
+		}
+
+		imports = append(imports, path.Join(module, filepath.ToSlash(rel)))
+	}
+
+	var buf bytes.Buffer
+	if err := allTemplate.Execute(&buf, imports); err != nil {
+		return "", err
+	}
+
+	filePath = filepath.Join(root, "internal", "solutions", "all", "all.go")
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", err
+	}
+
+	return filePath, writeSource(filePath, buf.Bytes())
+}
+
+// writeSource formats the given Go source & writes it to filePath.
+func writeSource(filePath string, source []byte) error {
+	formatted, err := format.Source(source)
+	if err != nil {
+		return fmt.Errorf("failed to format %s (%w)", filePath, err)
+	}
+
+	return ioutil.WriteFile(filePath, formatted, 0644)
+}
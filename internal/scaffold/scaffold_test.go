@@ -0,0 +1,142 @@
+package scaffold
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		existing map[string]string // files to create before generating, relative to the root
+		day      int
+
+		// outputs
+		expectedWritten []string
+		expectedErr     error
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		root, err := ioutil.TempDir("", "aoc-scaffold-test")
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		defer os.RemoveAll(root)
+
+		cfg.existing["go.mod"] = "module example.com/aoc\n\ngo 1.15\n"
+		for name, contents := range cfg.existing {
+			filePath := filepath.Join(root, name)
+			if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+				t.Fatalf("Got %v, expected nil", err)
+			}
+
+			if err := ioutil.WriteFile(filePath, []byte(contents), 0644); err != nil {
+				t.Fatalf("Got %v, expected nil", err)
+			}
+		}
+
+		written, err := Generate(Config{Root: root, Year: 2021, Day: cfg.day, Title: "Sonar Sweep"})
+		if !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+
+		var got []string
+		for _, filePath := range written {
+			rel, err := filepath.Rel(root, filePath)
+			if err != nil {
+				t.Fatalf("Got %v, expected nil", err)
+			}
+
+			got = append(got, filepath.ToSlash(rel))
+		}
+
+		if diff := cmp.Diff(cfg.expectedWritten, got); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+
+		if err != nil {
+			return // we're done
+		}
+
+		solution, err := ioutil.ReadFile(filepath.Join(root, "internal/solutions/2021/day1/solution.go"))
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		if !strings.Contains(string(solution), `solutions.Register(2021, 1, &Solution{}`) {
+			t.Errorf("Expected generated solution to register itself, got:\n%s", solution)
+		}
+
+		all, err := ioutil.ReadFile(filepath.Join(root, "internal/solutions/all/all.go"))
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		if !strings.Contains(string(all), `_ "example.com/aoc/internal/solutions/2021/day1"`) {
+			t.Errorf("Expected generated package to be imported, got:\n%s", all)
+		}
+	}
+
+	tests := map[string]Test{
+		"ok: new package": {
+			existing: map[string]string{},
+			day:      1,
+			expectedWritten: []string{
+				"internal/solutions/2021/day1/examples_test.go",
+				"internal/solutions/2021/day1/solution.go",
+				"internal/solutions/2021/day1/solution_test.go",
+				"internal/solutions/2021/day1/values.go",
+				"inputs/2021/day1/input",
+				"inputs/2021/day1/example1",
+				"inputs/2021/day1/example1.expected",
+				"internal/solutions/all/all.go",
+			},
+		},
+
+		"ok: replace stub, keeping input": {
+			existing: map[string]string{
+				"internal/solutions/2021/day1/solution.go": "package day1\n\n// Tags: []string{solutions.StubTag}\n",
+				"inputs/2021/day1/input":                   "199\n200\n",
+			},
+			day: 1,
+			expectedWritten: []string{
+				"internal/solutions/2021/day1/examples_test.go",
+				"internal/solutions/2021/day1/solution.go",
+				"internal/solutions/2021/day1/solution_test.go",
+				"internal/solutions/2021/day1/values.go",
+				"inputs/2021/day1/example1",
+				"inputs/2021/day1/example1.expected",
+				"internal/solutions/all/all.go",
+			},
+		},
+
+		"error: existing solution": {
+			existing: map[string]string{
+				"internal/solutions/2021/day1/solution.go": "package day1\n",
+			},
+			day:         1,
+			expectedErr: ErrPackageExists,
+		},
+
+		"error: invalid day": {
+			existing:    map[string]string{},
+			day:         26,
+			expectedErr: ErrInvalidDay,
+		},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
@@ -0,0 +1,170 @@
+package scaffold
+
+import "text/template"
+
+// templates used to generate a day package, mapped by file name.
+var dayTemplates = map[string]*template.Template{
+	"solution.go":      template.Must(template.New("solution.go").Parse(solutionTemplate)),
+	"values.go":        template.Must(template.New("values.go").Parse(valuesTemplate)),
+	"solution_test.go": template.Must(template.New("solution_test.go").Parse(testTemplate)),
+	"examples_test.go": template.Must(template.New("examples_test.go").Parse(examplesTestTemplate)),
+}
+
+var allTemplate = template.Must(template.New("all.go").Parse(`// Package all registers every solution with the solutions registry. It is meant to be
+// imported for its side effects only.
+package all
+
+import (
+{{- range .}}
+	_ "{{.}}"
+{{- end}}
+)
+`))
+
+const solutionTemplate = `package day{{.Day}}
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"{{.Module}}/internal/input"
+	"{{.Module}}/internal/solutions"
+)
+
+func init() {
+	solutions.Register({{.Year}}, {{.Day}}, &Solution{}, solutions.Metadata{
+		Title:   {{printf "%q" .Title}},
+		Tags:    []string{solutions.StubTag},
+		Runtime: solutions.Fast,
+	})
+}
+
+type Solution struct{}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
+
+	values, err := s.getValues(scanner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get problem values (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return s.part1(values) },
+		func(context.Context) solutions.Part { return s.part2(values) },
+	}, nil
+}
+
+func (s *Solution) getValues(scanner input.Scanner) (values []ProblemValue, err error) {
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		var v ProblemValue
+		if err := v.Unmarshal(line); err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func (s *Solution) part1(values []ProblemValue) solutions.Part {
+	// TODO
+	return solutions.Part{Err: solutions.ErrNotImplemented}
+}
+
+func (s *Solution) part2(values []ProblemValue) solutions.Part {
+	// TODO
+	return solutions.Part{Err: solutions.ErrNotImplemented}
+}
+`
+
+const valuesTemplate = `package day{{.Day}}
+
+type ProblemValue struct {
+	// TODO: define for this problem
+}
+
+func (v *ProblemValue) Unmarshal(line string) error {
+	// TODO
+
+	return nil
+}
+`
+
+const testTemplate = `package day{{.Day}}
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnmarshalProblemValue(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		line string
+
+		// outputs
+		expected    ProblemValue
+		expectedErr error
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		var got ProblemValue
+		err := got.Unmarshal(cfg.line)
+		if !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+
+		if err != nil {
+			return // we're done
+		}
+
+		if diff := cmp.Diff(cfg.expected, got); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+	}
+
+	tests := map[string]Test{
+		// TODO: add cases from the puzzle's examples
+		"ok: empty line": {
+			line:     "",
+			expected: ProblemValue{},
+		},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+`
+
+const examplesTestTemplate = `package day{{.Day}}
+
+import (
+	"testing"
+
+	"{{.Module}}/internal/solutions/solutionstest"
+)
+
+func TestExamples(t *testing.T) {
+	t.Parallel()
+
+	solutionstest.RunExamples(t, {{.Year}}, {{.Day}})
+}
+`
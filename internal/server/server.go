@@ -0,0 +1,405 @@
+// Package server exposes the registered solutions over an HTTP API.
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/logging"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+	ErrInputTooLarge    = errors.New("input too large")
+)
+
+const (
+	// DefaultTimeout is the default maximum time allowed to solve a puzzle.
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultMaxInputSize is the default maximum size of a puzzle input, in bytes.
+	DefaultMaxInputSize = 1 << 20
+)
+
+// Config holds the settings used by the server. Zero values are replaced by their
+// defaults.
+type Config struct {
+	// Timeout is the maximum time allowed to solve a puzzle in a single request.
+	Timeout time.Duration
+
+	// MaxInputSize is the maximum size of a request body, in bytes.
+	MaxInputSize int64
+}
+
+// New returns a handler serving the following endpoints:
+//
+//	GET  /healthz                       liveness check
+//	GET  /metrics                       request & solve counters, in Prometheus text format
+//	GET  /v1/days[?year=<year>]         registered solutions
+//	POST /v1/<year>/day/<n>[?part=<p>]  solve a puzzle with the request body as input
+func New(cfg Config) http.Handler {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	if cfg.MaxInputSize == 0 {
+		cfg.MaxInputSize = DefaultMaxInputSize
+	}
+
+	s := &server{Config: cfg, metrics: newMetrics()}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.handleHealth)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	mux.HandleFunc("/v1/days", s.handleDays)
+	mux.HandleFunc("/v1/", s.handleSolve)
+
+	return s.instrument(mux)
+}
+
+type server struct {
+	Config
+
+	metrics *metrics
+}
+
+// dayInfo describes a registered solution.
+type dayInfo struct {
+	Year    int      `json:"year"`
+	Day     int      `json:"day"`
+	Title   string   `json:"title"`
+	Tags    []string `json:"tags"`
+	Runtime string   `json:"runtime"`
+}
+
+// solveResponse holds the answers computed for a puzzle.
+type solveResponse struct {
+	Year       int          `json:"year"`
+	Day        int          `json:"day"`
+	DurationMS float64      `json:"duration_ms"`
+	Parts      []partResult `json:"parts"`
+	Logs       []string     `json:"logs,omitempty"` // only set with ?verbose=true
+}
+
+type partResult struct {
+	Part   int    `json:"part"`
+	Answer string `json:"answer,omitempty"`
+	Label  string `json:"label,omitempty"`
+	Error  string `json:"error,omitempty"`
+
+	Diagnostics []string `json:"diagnostics,omitempty"`
+	DurationMS  float64  `json:"duration_ms"` // excluding parsing
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	s.metrics.write(w)
+}
+
+func (s *server) handleDays(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		return
+	}
+
+	years := solutions.Years()
+	if yearStr := r.URL.Query().Get("year"); yearStr != "" {
+		year, err := strconv.Atoi(yearStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid year (%w)", err))
+			return
+		}
+
+		years = []int{year}
+	}
+
+	days := []dayInfo{}
+	for _, year := range years {
+		for _, entry := range solutions.List(year) {
+			days = append(days, dayInfo{
+				Year:    entry.Year,
+				Day:     entry.Day,
+				Title:   entry.Metadata.Title,
+				Tags:    entry.Metadata.Tags,
+				Runtime: entry.Metadata.Runtime.String(),
+			})
+		}
+	}
+
+	writeJSON(w, http.StatusOK, days)
+}
+
+func (s *server) handleSolve(w http.ResponseWriter, r *http.Request) {
+	// expected path: /v1/<year>/day/<n>
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) != 4 || segments[2] != "day" {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%w (%s)", ErrNotFound, r.URL.Path))
+		return
+	}
+
+	year, yearErr := strconv.Atoi(segments[1])
+	day, dayErr := strconv.Atoi(segments[3])
+	entry, ok := solutions.Get(year, day)
+	if yearErr != nil || dayErr != nil || !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%w (no solution for %s day %s)", ErrNotFound, segments[1], segments[3]))
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		return
+	}
+
+	var parts []int
+	if partStr := r.URL.Query().Get("part"); partStr != "" {
+		part, err := strconv.Atoi(partStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("%w (%q)", solutions.ErrInvalidPart, partStr))
+			return
+		}
+
+		parts = append(parts, part)
+	}
+
+	verbose := false
+	if verboseStr := r.URL.Query().Get("verbose"); verboseStr != "" {
+		v, err := strconv.ParseBool(verboseStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid verbose flag (%w)", err))
+			return
+		}
+
+		verbose = v
+	}
+
+	// read the whole input up front so that a slow client doesn't count towards the
+	// solution's timeout
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, s.MaxInputSize+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("failed to read input (%w)", err))
+		return
+	} else if int64(len(body)) > s.MaxInputSize {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("%w (limit is %d bytes)", ErrInputTooLarge, s.MaxInputSize))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.Timeout)
+	defer cancel()
+
+	var logs bytes.Buffer
+	if verbose {
+		ctx = logging.WithLogger(ctx, logging.New(&logs, logging.Debug))
+	}
+
+	start := time.Now()
+	result, err := solutions.Solve(ctx, entry.Solution, bytes.NewReader(body), parts...)
+	duration := time.Since(start)
+
+	if ctxErr := solutions.CheckContext(ctx); ctxErr != nil {
+		err = ctxErr
+	}
+
+	s.metrics.observeSolve(year, day, duration, err)
+
+	switch {
+	case errors.Is(err, solutions.ErrTimeout):
+		writeError(w, http.StatusGatewayTimeout, err)
+		return
+	case errors.Is(err, solutions.ErrInvalidPart):
+		writeError(w, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	response := solveResponse{
+		Year:       year,
+		Day:        day,
+		DurationMS: float64(duration) / float64(time.Millisecond),
+		Parts:      []partResult{},
+	}
+
+	if verbose && logs.Len() > 0 {
+		response.Logs = strings.Split(strings.TrimSuffix(logs.String(), "\n"), "\n")
+	}
+
+	for _, part := range result.Parts {
+		p := partResult{Part: part.Number, Label: part.Label, Diagnostics: part.Diagnostics, DurationMS: float64(part.Duration) / float64(time.Millisecond)}
+		if part.Err != nil {
+			p.Error = part.Err.Error()
+		} else {
+			p.Answer = fmt.Sprint(part.Answer)
+		}
+
+		response.Parts = append(response.Parts, p)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// instrument counts the requests handled by next by status code, and turns panics into
+// internal server errors.
+func (s *server) instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+		defer func() {
+			if p := recover(); p != nil {
+				writeError(sw, http.StatusInternalServerError, fmt.Errorf("solution panicked (%v)", p))
+			}
+
+			s.metrics.observeRequest(sw.status)
+		}()
+
+		next.ServeHTTP(sw, r)
+	})
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(v) // nothing left to report to if the client went away
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// metrics holds the counters reported by the metrics endpoint.
+type metrics struct {
+	mu sync.Mutex
+
+	requests map[int]int // by status code
+	solves   map[solveKey]*solveStats
+}
+
+type solveKey struct {
+	year int
+	day  int
+}
+
+type solveStats struct {
+	count    int
+	errors   int
+	duration time.Duration
+}
+
+func newMetrics() *metrics {
+	return &metrics{requests: map[int]int{}, solves: map[solveKey]*solveStats{}}
+}
+
+func (m *metrics) observeRequest(status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[status]++
+}
+
+func (m *metrics) observeSolve(year, day int, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := solveKey{year: year, day: day}
+	stats, ok := m.solves[key]
+	if !ok {
+		stats = &solveStats{}
+		m.solves[key] = stats
+	}
+
+	stats.count++
+	stats.duration += duration
+	if err != nil {
+		stats.errors++
+	}
+}
+
+func (m *metrics) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var statuses []int
+	for status := range m.requests {
+		statuses = append(statuses, status)
+	}
+
+	sort.Ints(statuses)
+
+	fmt.Fprintln(w, "# TYPE aoc_http_requests_total counter")
+	for _, status := range statuses {
+		fmt.Fprintf(w, "aoc_http_requests_total{code=\"%d\"} %d\n", status, m.requests[status])
+	}
+
+	var keys []solveKey
+	for key := range m.solves {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].year != keys[j].year {
+			return keys[i].year < keys[j].year
+		}
+
+		return keys[i].day < keys[j].day
+	})
+
+	fmt.Fprintln(w, "# TYPE aoc_solves_total counter")
+	for _, key := range keys {
+		fmt.Fprintf(w, "aoc_solves_total{year=\"%d\",day=\"%d\"} %d\n", key.year, key.day, m.solves[key].count)
+	}
+
+	fmt.Fprintln(w, "# TYPE aoc_solve_errors_total counter")
+	for _, key := range keys {
+		fmt.Fprintf(w, "aoc_solve_errors_total{year=\"%d\",day=\"%d\"} %d\n", key.year, key.day, m.solves[key].errors)
+	}
+
+	fmt.Fprintln(w, "# TYPE aoc_solve_duration_seconds_total counter")
+	for _, key := range keys {
+		fmt.Fprintf(w, "aoc_solve_duration_seconds_total{year=\"%d\",day=\"%d\"} %g\n", key.year, key.day, m.solves[key].duration.Seconds())
+	}
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/logging"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+// testYear is only used by the solutions registered for these tests.
+const testYear = 1
+
+func init() {
+	solutions.Register(testYear, 1, &sumSolution{}, solutions.Metadata{Title: "Sum", Tags: []string{"test"}})
+	solutions.Register(testYear, 2, &sleepSolution{}, solutions.Metadata{Title: "Sleep", Runtime: solutions.Slow})
+}
+
+// sumSolution sums the integers found on each line in part 1 and counts them in part 2,
+// logging the sum & reporting the lines counted as diagnostics.
+type sumSolution struct{}
+
+func (s *sumSolution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	lines, err := input.ReadLines(input.NewReaderScanner(ctx, r))
+	if err != nil {
+		return nil, err
+	}
+
+	var values []int
+	for _, line := range lines {
+		var value int
+		if err := json.Unmarshal([]byte(line), &value); err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return solutions.Parts{
+		func(ctx context.Context) solutions.Part {
+			sum := 0
+			for _, value := range values {
+				sum += value
+			}
+
+			logging.FromContext(ctx).Debugf("Sum of %d values: %d", len(values), sum)
+			return solutions.Part{Answer: sum, Label: "Sum"}
+		},
+		func(context.Context) solutions.Part {
+			return solutions.Part{Answer: len(values), Label: "Count", Diagnostics: []string{fmt.Sprintf("Counted %d lines", len(lines))}}
+		},
+	}, nil
+}
+
+// sleepSolution waits for its context to be done.
+type sleepSolution struct{}
+
+func (s *sleepSolution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	return solutions.Parts{
+		func(ctx context.Context) solutions.Part {
+			<-ctx.Done()
+			return solutions.Part{Err: solutions.CheckContext(ctx)}
+		},
+	}, nil
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		method string
+		target string
+		body   string
+
+		// outputs
+		expectedStatus int
+		expectedBody   interface{} // decoded JSON
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		handler := New(Config{Timeout: 10 * time.Millisecond, MaxInputSize: 16})
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(cfg.method, cfg.target, strings.NewReader(cfg.body)))
+
+		if recorder.Code != cfg.expectedStatus {
+			t.Errorf("Got %v, expected %v", recorder.Code, cfg.expectedStatus)
+		}
+
+		var got interface{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		// durations vary from one run to the next
+		if response, ok := got.(map[string]interface{}); ok {
+			delete(response, "duration_ms")
+
+			if parts, ok := response["parts"].([]interface{}); ok {
+				for _, part := range parts {
+					delete(part.(map[string]interface{}), "duration_ms")
+				}
+			}
+		}
+
+		if diff := cmp.Diff(cfg.expectedBody, got); diff != "" {
+			t.Errorf("Unexpected body (-expected +got):\n%s", diff)
+		}
+	}
+
+	tests := map[string]Test{
+		"health": {
+			method:         http.MethodGet,
+			target:         "/healthz",
+			expectedStatus: http.StatusOK,
+			expectedBody:   map[string]interface{}{"status": "ok"},
+		},
+		"days": {
+			method:         http.MethodGet,
+			target:         "/v1/days?year=1",
+			expectedStatus: http.StatusOK,
+			expectedBody: []interface{}{
+				map[string]interface{}{"year": 1.0, "day": 1.0, "title": "Sum", "tags": []interface{}{"test"}, "runtime": "fast"},
+				map[string]interface{}{"year": 1.0, "day": 2.0, "title": "Sleep", "tags": nil, "runtime": "slow"},
+			},
+		},
+		"solve": {
+			method:         http.MethodPost,
+			target:         "/v1/1/day/1",
+			body:           "1\n2\n3\n",
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"year": 1.0,
+				"day":  1.0,
+				"parts": []interface{}{
+					map[string]interface{}{"part": 1.0, "answer": "6", "label": "Sum"},
+					map[string]interface{}{"part": 2.0, "answer": "3", "label": "Count", "diagnostics": []interface{}{"Counted 3 lines"}},
+				},
+			},
+		},
+		"solve verbose": {
+			method:         http.MethodPost,
+			target:         "/v1/1/day/1?part=1&verbose=true",
+			body:           "1\n2\n3\n",
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"year":  1.0,
+				"day":   1.0,
+				"parts": []interface{}{map[string]interface{}{"part": 1.0, "answer": "6", "label": "Sum"}},
+				"logs":  []interface{}{"DEBUG Sum of 3 values: 6"},
+			},
+		},
+		"invalid verbose": {
+			method:         http.MethodPost,
+			target:         "/v1/1/day/1?verbose=loud",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"error": `invalid verbose flag (strconv.ParseBool: parsing "loud": invalid syntax)`},
+		},
+		"solve part": {
+			method:         http.MethodPost,
+			target:         "/v1/1/day/1?part=2",
+			body:           "1\n2\n3\n",
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"year":  1.0,
+				"day":   1.0,
+				"parts": []interface{}{map[string]interface{}{"part": 2.0, "answer": "3", "label": "Count", "diagnostics": []interface{}{"Counted 3 lines"}}},
+			},
+		},
+		"invalid part": {
+			method:         http.MethodPost,
+			target:         "/v1/1/day/1?part=3",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"error": "invalid part (3, expected 1 to 2)"},
+		},
+		"unparseable input": {
+			method:         http.MethodPost,
+			target:         "/v1/1/day/1",
+			body:           "one\n",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   map[string]interface{}{"error": "invalid character 'o' looking for beginning of value"},
+		},
+		"input too large": {
+			method:         http.MethodPost,
+			target:         "/v1/1/day/1",
+			body:           strings.Repeat("1\n", 9),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   map[string]interface{}{"error": "input too large (limit is 16 bytes)"},
+		},
+		"timeout": {
+			method:         http.MethodPost,
+			target:         "/v1/1/day/2",
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedBody:   map[string]interface{}{"error": "solution timed out (context deadline exceeded)"},
+		},
+		"unknown day": {
+			method:         http.MethodPost,
+			target:         "/v1/1/day/3",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   map[string]interface{}{"error": "not found (no solution for 1 day 3)"},
+		},
+		"wrong method": {
+			method:         http.MethodGet,
+			target:         "/v1/1/day/1",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   map[string]interface{}{"error": "method not allowed"},
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+func TestServerMetrics(t *testing.T) {
+	t.Parallel()
+
+	handler := New(Config{})
+
+	for _, body := range []string{"1\n", "x\n"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/1/day/1", strings.NewReader(body)))
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, err := ioutil.ReadAll(recorder.Body)
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	for _, expected := range []string{
+		`aoc_http_requests_total{code="200"} 1`,
+		`aoc_http_requests_total{code="422"} 1`,
+		`aoc_solves_total{year="1",day="1"} 2`,
+		`aoc_solve_errors_total{year="1",day="1"} 1`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("Got %q, expected it to contain %q", body, expected)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package day1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+var (
+	ErrNoMatch = errors.New("no entries sum to 2020")
+)
+
+func init() {
+	solutions.Register(2020, 1, &Solution{}, solutions.Metadata{
+		Title:   "Report Repair",
+		Tags:    []string{"brute-force"},
+		Runtime: solutions.Fast,
+	})
+}
+
+type Solution struct{}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	values, err := s.getValues(input.NewReaderScanner(ctx, r))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get values (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return s.part1(values) },
+		func(context.Context) solutions.Part { return s.part2(values) },
+	}, nil
+}
+
+func (s *Solution) getValues(scanner input.Scanner) (values []int, err error) {
+	defer scanner.Close()
+
+	for scanner.Scan() {
+		strValue := strings.TrimSpace(scanner.Text())
+
+		value, err := strconv.Atoi(strValue)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func (s *Solution) part1(values []int) solutions.Part {
+	for i := range values {
+		for j := i + 1; j < len(values); j++ {
+			if values[i]+values[j] == 2020 {
+				return solutions.Part{
+					Answer:      values[i] * values[j],
+					Label:       fmt.Sprintf("%d*%d", values[i], values[j]),
+					Diagnostics: []string{fmt.Sprintf("Found %d (%d) + %d (%d) = 2020", values[i], i, values[j], j)},
+				}
+			}
+		}
+	}
+
+	return solutions.Part{Err: ErrNoMatch}
+}
+
+func (s *Solution) part2(values []int) solutions.Part {
+	for i := range values {
+		for j := i + 1; j < len(values); j++ {
+			for k := j + 1; k < len(values); k++ {
+				if values[i]+values[j]+values[k] == 2020 {
+					return solutions.Part{
+						Answer:      values[i] * values[j] * values[k],
+						Label:       fmt.Sprintf("%d*%d*%d", values[i], values[j], values[k]),
+						Diagnostics: []string{fmt.Sprintf("Found %d (%d) + %d (%d) + %d (%d) = 2020", values[i], i, values[j], j, values[k], k)},
+					}
+				}
+			}
+		}
+	}
+
+	return solutions.Part{Err: ErrNoMatch}
+}
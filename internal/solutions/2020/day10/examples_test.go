@@ -0,0 +1,13 @@
+package day10
+
+import (
+	"testing"
+
+	"github.com/segwin/adventofcode-2020/internal/solutions/solutionstest"
+)
+
+func TestExamples(t *testing.T) {
+	t.Parallel()
+
+	solutionstest.RunExamples(t, 2020, 10)
+}
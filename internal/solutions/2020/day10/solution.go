@@ -2,41 +2,51 @@ package day10
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
 )
 
+var (
+	ErrUnbridgeableDifference = errors.New("found unbridgeable difference between adapters")
+	ErrMissingDifference      = errors.New("expected joltage difference not found")
+	ErrNoAdapters             = errors.New("no adapters found")
+)
+
+func init() {
+	solutions.Register(2020, 10, &Solution{}, solutions.Metadata{
+		Title:   "Adapter Array",
+		Tags:    []string{"combinatorics"},
+		Runtime: solutions.Fast,
+	})
+}
+
 type Solution struct{}
 
-func (s *Solution) Run(ctx context.Context, inputFile string) {
-	scanner, err := input.NewFileScanner(ctx, inputFile)
-	if err != nil {
-		fmt.Printf("ERROR: Failed to create input file scanner: %v\n", err)
-		os.Exit(1)
-	}
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
 
 	adapters, err := s.getAdapters(scanner)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to get adapters: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to get adapters (%w)", err)
 	}
 
-	s.part1(adapters)
-	s.part2(adapters)
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return s.part1(adapters) },
+		func(context.Context) solutions.Part { return s.part2(adapters) },
+	}, nil
 }
 
 func (s *Solution) getAdapters(scanner input.Scanner) (adapters Adapters, err error) {
 	for scanner.Scan() {
-		if err := scanner.Err(); err != nil {
-			return nil, err
-		}
-
 		line := strings.TrimSpace(scanner.Text())
 		joltage, err := strconv.Atoi(line)
 		if err != nil {
@@ -46,6 +56,14 @@ func (s *Solution) getAdapters(scanner input.Scanner) (adapters Adapters, err er
 		adapters = append(adapters, Adapter(joltage))
 	}
 
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(adapters) == 0 {
+		return nil, ErrNoAdapters
+	}
+
 	// sort from lowest to highest
 	sort.Sort(adapters)
 
@@ -55,9 +73,7 @@ func (s *Solution) getAdapters(scanner input.Scanner) (adapters Adapters, err er
 	return adapters, nil
 }
 
-func (s *Solution) part1(adapters Adapters) {
-	fmt.Println("\nPART 1")
-
+func (s *Solution) part1(adapters Adapters) solutions.Part {
 	differences := map[int]int{}
 	for i := 0; i < len(adapters); i++ {
 		referenceJoltage := 0
@@ -67,28 +83,26 @@ func (s *Solution) part1(adapters Adapters) {
 
 		difference := int(adapters[i]) - referenceJoltage
 		if difference > 3 {
-			fmt.Printf("  ERROR: Found unbridgeable difference between adapters (%d jolts)\n", difference)
-			os.Exit(1)
+			return solutions.Part{Err: fmt.Errorf("%w (%d jolts)", ErrUnbridgeableDifference, difference)}
 		}
 
 		differences[difference]++
 	}
 
-	fmt.Printf("  Found %d differences\n", len(differences))
-
 	for _, expectedDiff := range []int{1, 3} {
 		if _, ok := differences[expectedDiff]; !ok {
-			fmt.Printf("  ERROR: No %d jolt differences found\n", expectedDiff)
-			os.Exit(1)
+			return solutions.Part{Err: fmt.Errorf("%w (%d jolts)", ErrMissingDifference, expectedDiff)}
 		}
 	}
 
-	fmt.Printf("  RESULT: Product of 1 jolt & 3 jolt differences is %d\n", differences[1]*differences[3])
+	return solutions.Part{
+		Answer:      differences[1] * differences[3],
+		Label:       "Product of 1 jolt & 3 jolt differences",
+		Diagnostics: []string{fmt.Sprintf("Found %d differences", len(differences))},
+	}
 }
 
-func (s *Solution) part2(adapters Adapters) {
-	fmt.Println("\nPART 2")
-
+func (s *Solution) part2(adapters Adapters) solutions.Part {
 	adapters = append(Adapters{0}, adapters...)
 
 	// identify all choke points in the graph, i.e. points where next is 3 away
@@ -165,7 +179,7 @@ func (s *Solution) part2(adapters Adapters) {
 		branches.Mul(branches, big.NewInt(0).Sub(idealBranches, totalRemoved))
 	}
 
-	fmt.Printf("  RESULT: Found %s unique branches\n", branches.Text(10))
+	return solutions.Part{Answer: branches, Label: "Unique branches"}
 }
 
 func (s *Solution) getHoles(adapters Adapters) (holes []int) {
@@ -0,0 +1,120 @@
+package day11
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+func init() {
+	solutions.Register(2020, 11, &Solution{}, solutions.Metadata{
+		Title:   "Seating System",
+		Tags:    []string{"grid", "cellular-automaton"},
+		Runtime: solutions.Moderate,
+	})
+}
+
+type Solution struct{}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
+
+	layout, err := s.getLayout(scanner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get layout (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(ctx context.Context) solutions.Part { return s.part1(ctx, layout) },
+		func(ctx context.Context) solutions.Part { return s.part2(ctx, layout) },
+	}, nil
+}
+
+func (s *Solution) getLayout(scanner input.Scanner) (layout Layout, err error) {
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		row, err := ParseRow(line)
+		if err != nil {
+			return nil, err
+		}
+
+		layout = append(layout, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return layout, nil
+}
+
+func (s *Solution) part1(ctx context.Context, layout Layout) solutions.Part {
+	return s.evolveUntilStable(ctx, layout, 4, false)
+}
+
+func (s *Solution) part2(ctx context.Context, layout Layout) solutions.Part {
+	return s.evolveUntilStable(ctx, layout, 5, true)
+}
+
+func (s *Solution) evolveUntilStable(ctx context.Context, layout Layout, tolerateOccupied int, skipAdjacentFloors bool) solutions.Part {
+	prevLayout := layout.Clone()
+	generation := 0
+	for {
+		if err := solutions.CheckContext(ctx); err != nil {
+			return solutions.Part{Err: err}
+		}
+
+		newLayout := s.evolveLayout(prevLayout, tolerateOccupied, skipAdjacentFloors)
+		if newLayout.Equals(prevLayout) {
+			// reached equilibrium
+			return solutions.Part{
+				Answer:      newLayout.Count(Occupied),
+				Label:       "Occupied seats",
+				Diagnostics: []string{fmt.Sprintf("Evolution took %d generations", generation)},
+			}
+		}
+
+		prevLayout = newLayout
+		generation++
+	}
+}
+
+func (s *Solution) evolveLayout(prevLayout Layout, tolerateOccupied int, skipAdjacentFloors bool) (newLayout Layout) {
+	newLayout = prevLayout.Clone()
+
+	for row := range prevLayout {
+		for col := range prevLayout[row] {
+			adjacentSeats, err := prevLayout.AdjacentSeats(row, col, skipAdjacentFloors)
+			if err != nil {
+				// row & col are always within prevLayout's bounds, so this can't happen
+				panic(err)
+			}
+
+			occupiedAdjacent := prevLayout.FilterByType(adjacentSeats, Occupied)
+
+			switch prevLayout[row][col] {
+			case Empty:
+				if len(occupiedAdjacent) == 0 {
+					// this seat becomes occupied
+					newLayout[row][col] = Occupied
+				}
+
+			case Occupied:
+				if len(occupiedAdjacent) >= tolerateOccupied {
+					// this seat becomes empty
+					newLayout[row][col] = Empty
+				}
+
+			case Floor:
+				// no action
+			}
+		}
+	}
+
+	return newLayout
+}
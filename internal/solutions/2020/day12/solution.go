@@ -0,0 +1,124 @@
+package day12
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/segwin/adventofcode-2020/internal/geometry"
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+func init() {
+	solutions.Register(2020, 12, &Solution{}, solutions.Metadata{
+		Title:   "Rain Risk",
+		Tags:    []string{"geometry"},
+		Runtime: solutions.Fast,
+	})
+}
+
+type Solution struct{}
+
+// Instruction is a single navigation instruction, e.g. "F10".
+type Instruction struct {
+	Direction rune
+	Magnitude int64
+}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
+
+	lines, err := input.ReadLines(scanner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input (%w)", err)
+	}
+
+	instructions, err := s.getInstructions(lines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse instructions (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return s.part1(instructions) },
+		func(context.Context) solutions.Part { return s.part2(instructions) },
+	}, nil
+}
+
+func (s *Solution) getInstructions(lines []string) (instructions []Instruction, err error) {
+	for i, line := range lines {
+		if len(line) < 2 {
+			return nil, &input.LineError{Line: i + 1, Err: fmt.Errorf("%w (%s)", ErrInvalidDirection, line)}
+		}
+
+		magnitude, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, &input.LineError{Line: i + 1, Err: err}
+		}
+
+		instructions = append(instructions, Instruction{Direction: rune(line[0]), Magnitude: int64(magnitude)})
+	}
+
+	return instructions, nil
+}
+
+func (s *Solution) transform(instructions []Instruction, ship, waypoint geometry.Point, part2 bool) (finalPosition geometry.Point, err error) {
+	for _, instruction := range instructions {
+		ship, waypoint, err = Transform(instruction.Direction, instruction.Magnitude, ship, waypoint, part2)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ship, nil
+}
+
+func (s *Solution) part1(instructions []Instruction) solutions.Part {
+	initialShip := geometry.NewInts(0, 0)
+	initialWaypoint := geometry.NewInts(1, 0) // start facing east
+
+	finalShip, err := s.transform(instructions, initialShip, initialWaypoint, false)
+	if err != nil {
+		return solutions.Part{Err: err}
+	}
+
+	return s.result(finalShip)
+}
+
+func (s *Solution) part2(instructions []Instruction) solutions.Part {
+	initialShip := geometry.NewInts(0, 0)
+	initialWaypoint := geometry.NewInts(10, 1) // start facing east
+
+	finalShip, err := s.transform(instructions, initialShip, initialWaypoint, true)
+	if err != nil {
+		return solutions.Part{Err: err}
+	}
+
+	return s.result(finalShip)
+}
+
+// result builds the part result for the given final ship position: the answer is
+// its Manhattan distance from the origin.
+func (s *Solution) result(finalShip geometry.Point) solutions.Part {
+	directionX := East
+	if finalShip.MustGet(0).Int() < 0 {
+		directionX = West
+		finalShip.MustSet(geometry.Int(-finalShip.MustGet(0).Int()), 0)
+	}
+
+	directionY := North
+	if finalShip.MustGet(1).Int() < 0 {
+		directionY = South
+		finalShip.MustSet(geometry.Int(-finalShip.MustGet(1).Int()), 1)
+	}
+
+	x, y := finalShip.MustGet(0).Int(), finalShip.MustGet(1).Int()
+
+	return solutions.Part{
+		Answer:      x + y,
+		Label:       "Manhattan distance from origin",
+		Diagnostics: []string{fmt.Sprintf("Final position: %s%d, %s%d", directionX, x, directionY, y)},
+	}
+}
@@ -0,0 +1,112 @@
+package day13
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+var (
+	ErrInvalidNotes = errors.New("invalid input notes")
+)
+
+func init() {
+	solutions.Register(2020, 13, &Solution{}, solutions.Metadata{
+		Title:   "Shuttle Search",
+		Tags:    []string{"number-theory"},
+		Runtime: solutions.Fast,
+	})
+}
+
+type Solution struct{}
+
+// Notes holds the parsed contents of the notes given as input.
+type Notes struct {
+	EarliestDeparture time.Duration
+	BusList           *BusList
+	Schedule          *Schedule
+}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
+
+	lines, err := input.ReadLines(scanner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input (%w)", err)
+	}
+
+	notes, err := s.parseNotes(lines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get problem values (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return s.part1(notes) },
+		func(context.Context) solutions.Part { return s.part2(notes) },
+	}, nil
+}
+
+func (s *Solution) parseNotes(lines []string) (notes *Notes, err error) {
+	if len(lines) != 2 {
+		return nil, fmt.Errorf("%w (got %d lines, expected 2)", ErrInvalidNotes, len(lines))
+	}
+
+	estimatedMinutes, err := strconv.Atoi(lines[0])
+	if err != nil {
+		return nil, &input.LineError{Line: 1, Err: err}
+	}
+
+	notes = &Notes{
+		EarliestDeparture: time.Minute * time.Duration(estimatedMinutes),
+		BusList:           &BusList{},
+		Schedule:          &Schedule{},
+	}
+
+	if err := notes.BusList.Unmarshal(lines[1]); err != nil {
+		return nil, &input.LineError{Line: 2, Err: err}
+	}
+
+	if err := notes.Schedule.Unmarshal(lines[1]); err != nil {
+		return nil, &input.LineError{Line: 2, Err: err}
+	}
+
+	return notes, nil
+}
+
+func (s *Solution) part1(notes *Notes) solutions.Part {
+	minWaitTime := time.Duration(math.MaxInt64)
+	var fastestBus *Bus
+
+	for _, bus := range notes.BusList.Buses {
+		waitTime := bus.DepartsIn(notes.EarliestDeparture)
+		if waitTime < minWaitTime {
+			fastestBus = bus
+			minWaitTime = waitTime
+		}
+	}
+
+	waitMinutes := int(minWaitTime.Minutes())
+
+	return solutions.Part{
+		Answer:      fastestBus.ID * waitMinutes,
+		Label:       "ID * wait minutes",
+		Diagnostics: []string{fmt.Sprintf("Bus %d is the fastest with a %d minute wait time", fastestBus.ID, waitMinutes)},
+	}
+}
+
+func (s *Solution) part2(notes *Notes) solutions.Part {
+	lowestTime, err := notes.Schedule.FindLowestTime()
+	if err != nil {
+		return solutions.Part{Err: fmt.Errorf("failed to compute lowest time (%w)", err)}
+	}
+
+	return solutions.Part{Answer: lowestTime, Label: "Minutes until all buses coincide with the schedule"}
+}
@@ -1,16 +1,23 @@
 package day14
 
 import (
+	"context"
 	"errors"
 	"fmt"
+
+	"github.com/segwin/adventofcode-2020/internal/solutions"
 )
 
 var (
 	ErrInvalidBitmaskCharacter = errors.New("invalid character in encoded bitmask")
 	ErrBitmaskTooLong          = errors.New("bitmask is more than 36 bits long")
+	ErrBitmaskTooShort         = errors.New("bitmask is less than 36 bits long")
 	ErrInvalidBitPermutations  = errors.New("got invalid number of bit permutations")
 )
 
+// checkInterval is the number of bitsets masked between two context checks.
+const checkInterval = 1 << 12
+
 type BitSetter func(currentBit Bit) (newBits []Bit)
 
 var (
@@ -32,6 +39,8 @@ func (b *Bitmask) Unmarshal(encodedMask string, part1 bool) error {
 	b.bitSetters = make([]BitSetter, 0, 36) // size assumption is always valid for part 1
 	if len(encodedMask) > cap(b.bitSetters) {
 		return fmt.Errorf("%w (%d)", ErrBitmaskTooLong, len(encodedMask))
+	} else if len(encodedMask) < cap(b.bitSetters) {
+		return fmt.Errorf("%w (%d)", ErrBitmaskTooShort, len(encodedMask)) // can't be applied to 36-bit values
 	}
 
 	// reverse iterate through encoded mask: it's encoded left-to-right but bitsets
@@ -65,7 +74,9 @@ func (b *Bitmask) Unmarshal(encodedMask string, part1 bool) error {
 
 func (b *Bitmask) Len() int { return len(b.bitSetters) }
 
-func (b *Bitmask) Apply(bits *Bitset) (maskedBitsets []*Bitset, err error) {
+// Apply returns every bitset obtained by masking bits. A mask with N wildcards expands to
+// 2^N bitsets, so ctx is checked periodically while they are built.
+func (b *Bitmask) Apply(ctx context.Context, bits *Bitset) (maskedBitsets []*Bitset, err error) {
 	if bits.Len() != b.Len() {
 		return nil, ErrMismatchedBitsetLengths
 	}
@@ -76,7 +87,13 @@ func (b *Bitmask) Apply(bits *Bitset) (maskedBitsets []*Bitset, err error) {
 	}
 
 	for bitIdx, mutate := range b.bitSetters {
-		for _, bitset := range maskedBitsets {
+		for i, bitset := range maskedBitsets {
+			if i%checkInterval == 0 {
+				if err := solutions.CheckContext(ctx); err != nil {
+					return nil, err
+				}
+			}
+
 			newBits := mutate(bitset.bits[bitIdx])
 
 			if len(newBits) < 1 || len(newBits) > 2 {
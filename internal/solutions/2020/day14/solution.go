@@ -0,0 +1,177 @@
+package day14
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+var (
+	ErrUnexpectedPermutations = errors.New("unexpected number of permutations")
+	ErrBadInputLine           = errors.New("invalid input line")
+	ErrBadMemFormat           = errors.New("invalid mem[N] format")
+)
+
+func init() {
+	solutions.Register(2020, 14, &Solution{}, solutions.Metadata{
+		Title:   "Docking Data",
+		Tags:    []string{"bitmask"},
+		Runtime: solutions.Moderate,
+	})
+}
+
+type Solution struct{}
+
+// Operation is a single line of the initialisation program: either a mask update
+// (if Mask is set) or a write of Value to Address.
+type Operation struct {
+	Mask string
+
+	Address *Bitset
+	Value   *Bitset
+}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
+
+	lines, err := input.ReadLines(scanner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input (%w)", err)
+	}
+
+	program, err := s.parseProgram(lines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get problem values (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(ctx context.Context) solutions.Part { return s.run(ctx, program, true) },
+		func(ctx context.Context) solutions.Part { return s.run(ctx, program, false) },
+	}, nil
+}
+
+func (s *Solution) parseProgram(lines []string) (program []Operation, err error) {
+	for i, line := range lines {
+		// expect format: "<operation> = <value>"
+		words := strings.Split(line, " = ")
+		if len(words) != 2 {
+			return nil, &input.LineError{Line: i + 1, Err: fmt.Errorf("%w (%q)", ErrBadInputLine, line)}
+		}
+
+		if words[0] == "mask" {
+			// masks are only applied when running the program, but validate them up front
+			if err := (&Bitmask{}).Unmarshal(words[1], false); err != nil {
+				return nil, &input.LineError{Line: i + 1, Err: err}
+			}
+
+			program = append(program, Operation{Mask: words[1]})
+			continue
+		}
+
+		address, value, err := s.parseMem(words[0], words[1])
+		if err != nil {
+			return nil, &input.LineError{Line: i + 1, Err: err}
+		}
+
+		program = append(program, Operation{Address: address, Value: value})
+	}
+
+	return program, nil
+}
+
+func (s *Solution) run(ctx context.Context, program []Operation, part1 bool) solutions.Part {
+	memory, err := s.runProgram(ctx, program, part1)
+	if err != nil {
+		return solutions.Part{Err: fmt.Errorf("failed to run program (%w)", err)}
+	}
+
+	// sum all values in memory
+	sum := int64(0)
+	for _, value := range memory {
+		sum += value.Int()
+	}
+
+	return solutions.Part{Answer: sum, Label: "Sum of all stored values"}
+}
+
+func (s *Solution) runProgram(ctx context.Context, program []Operation, part1 bool) (memory map[int64]*Bitset, err error) {
+	memory = map[int64]*Bitset{}
+
+	currentMask := Bitmask{}
+	for _, operation := range program {
+		// each operation can expand to many addresses in part 2, so check in between
+		if err := solutions.CheckContext(ctx); err != nil {
+			return nil, err
+		}
+
+		if operation.Mask != "" {
+			// simpler case: just update the mask (which is interpreted differently in each part)
+			if err := currentMask.Unmarshal(operation.Mask, part1); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		// case 2: memory
+		address, value := operation.Address, operation.Value
+
+		if part1 {
+			maskedValues, err := currentMask.Apply(ctx, value)
+			if err != nil {
+				return nil, err
+			}
+
+			// len(maskedValues) can only ever be 1
+			if len(maskedValues) != 1 {
+				return nil, ErrUnexpectedPermutations
+			}
+
+			memory[address.Int()] = maskedValues[0]
+		} else {
+			maskedAddresses, err := currentMask.Apply(ctx, address)
+			if err != nil {
+				return nil, err
+			}
+
+			for i, maskedAddress := range maskedAddresses {
+				if i%checkInterval == 0 {
+					if err := solutions.CheckContext(ctx); err != nil {
+						return nil, err
+					}
+				}
+
+				memory[maskedAddress.Int()] = value
+			}
+		}
+	}
+
+	return memory, nil
+}
+
+func (s *Solution) parseMem(key, value string) (address *Bitset, bitset *Bitset, err error) {
+	matches := regexp.MustCompile(`mem\[(?P<address>[0-9]+)\]`).FindStringSubmatch(key)
+	if len(matches) != 2 {
+		return nil, nil, fmt.Errorf("%w (%q)", ErrBadMemFormat, key)
+	}
+
+	addressStr := matches[1]
+	address = &Bitset{}
+	if err := address.Unmarshal(addressStr, 36); err != nil {
+		return nil, nil, err
+	}
+
+	bitset = &Bitset{}
+	if err := bitset.Unmarshal(value, 36); err != nil {
+		return nil, nil, err
+	}
+
+	return address, bitset, nil
+}
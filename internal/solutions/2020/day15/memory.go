@@ -1,18 +1,25 @@
 package day15
 
 import (
+	"context"
 	"errors"
 	"strconv"
 	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/solutions"
 )
 
 var (
 	ErrNoStartingNumbers = errors.New("no starting numbers in memory")
 )
 
+// checkInterval is the number of generations recited between two context checks.
+const checkInterval = 1 << 16
+
 type Memory interface {
-	Unmarshal(startingNumbers string) error
-	Recite(untilGeneration int, lastSpoken int) (newLastSpoken int)
+	// Recite numbers until the given generation is reached. An error is returned if
+	// ctx is done before then.
+	Recite(ctx context.Context, untilGeneration int, lastSpoken int) (newLastSpoken int, err error)
 }
 
 type memory struct {
@@ -22,32 +29,40 @@ type memory struct {
 	generation      int
 }
 
-func NewMemory() Memory {
+func NewMemory(startingNumbers []int) Memory {
 	return &memory{
+		StartingNumbers: append([]int(nil), startingNumbers...), // consumed while reciting
 		previousNumbers: map[int]int{},
 		generation:      0,
 	}
 }
 
-func (m *memory) Unmarshal(startingNumbers string) error {
-	for _, numberStr := range strings.Split(startingNumbers, ",") {
+// ParseStartingNumbers parses the given comma-separated list of starting numbers.
+func ParseStartingNumbers(encoded string) (startingNumbers []int, err error) {
+	for _, numberStr := range strings.Split(encoded, ",") {
 		number, err := strconv.Atoi(numberStr)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		m.StartingNumbers = append(m.StartingNumbers, number)
+		startingNumbers = append(startingNumbers, number)
 	}
 
-	return nil
+	return startingNumbers, nil
 }
 
-func (m *memory) Recite(untilGeneration int, lastSpoken int) (newLastSpoken int) {
+func (m *memory) Recite(ctx context.Context, untilGeneration int, lastSpoken int) (newLastSpoken int, err error) {
 	for m.generation < untilGeneration {
+		if m.generation%checkInterval == 0 {
+			if err := solutions.CheckContext(ctx); err != nil {
+				return 0, err
+			}
+		}
+
 		lastSpoken = m.reciteOne(lastSpoken)
 	}
 
-	return lastSpoken
+	return lastSpoken, nil
 }
 
 func (m *memory) reciteOne(lastSpoken int) (numberSpoken int) {
@@ -88,6 +103,10 @@ func (m *memory) findPrevious(number int) (generationsAgo int, ok bool) {
 }
 
 func (m *memory) remember(newNumber int) {
-	m.previousNumbers[newNumber] = m.generation
+	// nothing has been spoken before the first generation, so there's nothing to remember
+	if m.generation > 0 {
+		m.previousNumbers[newNumber] = m.generation
+	}
+
 	m.generation++
 }
@@ -0,0 +1,72 @@
+package day15
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+var (
+	ErrInvalidInput = errors.New("invalid input file")
+)
+
+func init() {
+	solutions.Register(2020, 15, &Solution{}, solutions.Metadata{
+		Title:   "Rambunctious Recitation",
+		Tags:    []string{"memory-game"},
+		Runtime: solutions.Slow,
+	})
+}
+
+type Solution struct{}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
+
+	line, err := s.readInput(scanner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get input line (%w)", err)
+	}
+
+	startingNumbers, err := ParseStartingNumbers(line)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse starting numbers (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(ctx context.Context) solutions.Part { return s.play(ctx, startingNumbers, 2020) },
+		func(ctx context.Context) solutions.Part { return s.play(ctx, startingNumbers, 30000000) },
+	}, nil
+}
+
+func (s *Solution) readInput(scanner input.Scanner) (line string, err error) {
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSpace(scanner.Text()))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	if len(lines) != 1 {
+		return "", fmt.Errorf("%w (got %d lines)", ErrInvalidInput, len(lines))
+	}
+
+	return lines[0], nil
+}
+
+func (s *Solution) play(ctx context.Context, startingNumbers []int, generations int) solutions.Part {
+	lastSpoken, err := NewMemory(startingNumbers).Recite(ctx, generations, 0)
+	if err != nil {
+		return solutions.Part{Err: err}
+	}
+
+	return solutions.Part{Answer: lastSpoken, Label: fmt.Sprintf("Number spoken on %dth iteration", generations)}
+}
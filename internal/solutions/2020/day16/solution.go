@@ -2,41 +2,56 @@ package day16
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
 	"strings"
 
 	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
 )
 
+var (
+	ErrMissingTicket = errors.New("input does not contain my ticket")
+)
+
+func init() {
+	solutions.Register(2020, 16, &Solution{}, solutions.Metadata{
+		Title:   "Ticket Translation",
+		Tags:    []string{"parsing", "constraints"},
+		Runtime: solutions.Fast,
+	})
+}
+
 type Solution struct{}
 
-func (s *Solution) Run(ctx context.Context, inputFile string) {
-	scanner, err := input.NewFileScanner(ctx, inputFile)
-	if err != nil {
-		fmt.Printf("ERROR: Failed to create input file scanner: %v\n", err)
-		os.Exit(1)
-	}
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
 
 	fields, myTicket, otherTickets, err := s.parseLines(scanner)
 	if err != nil {
-		fmt.Printf("ERROR: Failed to get problem values: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to get problem values (%w)", err)
 	}
 
-	validTickets := s.part1(fields, otherTickets)
-	s.part2(fields, myTicket, validTickets)
+	return solutions.Parts{
+		func(context.Context) solutions.Part {
+			result, _ := s.part1(fields, otherTickets)
+			return result
+		},
+		func(context.Context) solutions.Part {
+			// part 2 only considers the tickets found to be valid in part 1
+			_, validTickets := s.part1(fields, otherTickets)
+			return s.part2(fields, myTicket, validTickets)
+		},
+	}, nil
 }
 
 func (s *Solution) parseLines(scanner input.Scanner) (fields []*TicketField, myTicket *RawTicket, otherTickets []*RawTicket, err error) {
 	stage := Rules
 
 	for scanner.Scan() {
-		if err := scanner.Err(); err != nil {
-			return nil, nil, nil, err
-		}
-
 		line := strings.TrimSpace(scanner.Text())
 
 		switch line {
@@ -77,12 +92,14 @@ func (s *Solution) parseLines(scanner input.Scanner) (fields []*TicketField, myT
 		}
 	}
 
+	if err := scanner.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+
 	return fields, myTicket, otherTickets, nil
 }
 
-func (s *Solution) part1(fields []*TicketField, tickets []*RawTicket) (validTickets []*RawTicket) {
-	fmt.Println("\nPART 1")
-
+func (s *Solution) part1(fields []*TicketField, tickets []*RawTicket) (result solutions.Part, validTickets []*RawTicket) {
 	errorRate := 0
 	for _, ticket := range tickets {
 		invalidValues := ticket.InvalidValues(fields)
@@ -95,12 +112,13 @@ func (s *Solution) part1(fields []*TicketField, tickets []*RawTicket) (validTick
 		}
 	}
 
-	fmt.Printf("  RESULT: Got an error rate of %d\n", errorRate)
-	return validTickets
+	return solutions.Part{Answer: errorRate, Label: "Ticket scanning error rate"}, validTickets
 }
 
-func (s *Solution) part2(fields []*TicketField, myTicket *RawTicket, validTickets []*RawTicket) {
-	fmt.Println("\nPART 2")
+func (s *Solution) part2(fields []*TicketField, myTicket *RawTicket, validTickets []*RawTicket) solutions.Part {
+	if myTicket == nil {
+		return solutions.Part{Err: ErrMissingTicket}
+	}
 
 	// initialise map of potential positions with all possibilities
 	numPositions := len(myTicket.Values)
@@ -113,16 +131,16 @@ func (s *Solution) part2(fields []*TicketField, myTicket *RawTicket, validTicket
 
 	potentialPositions.Intersect(fields, myTicket)
 
-	fmt.Println()
-	for field, positions := range potentialPositions {
+	var diagnostics []string
+	for _, field := range fields {
+		positions := potentialPositions[field]
 		positionStrings := make([]string, len(positions))
 		for i, position := range positions {
 			positionStrings[i] = strconv.Itoa(position)
 		}
 
-		fmt.Printf("%s => %s\n", field.Name, strings.Join(positionStrings, ", "))
+		diagnostics = append(diagnostics, fmt.Sprintf("%s => %s", field.Name, strings.Join(positionStrings, ", ")))
 	}
-	fmt.Println()
 
 	fieldPositions := potentialPositions.Collapse()
 
@@ -135,5 +153,5 @@ func (s *Solution) part2(fields []*TicketField, myTicket *RawTicket, validTicket
 		product *= myTicket.Values[position]
 	}
 
-	fmt.Printf("  RESULT: The product of all departure fields on my ticket is %d\n", product)
+	return solutions.Part{Answer: product, Label: "Product of all departure fields on my ticket", Diagnostics: diagnostics}
 }
@@ -77,35 +77,26 @@ func (t *RawTicket) Unmarshal(line string) error {
 }
 
 func (t *RawTicket) InvalidValues(fields []*TicketField) (invalidValues []int) {
-	takenBy := map[*TicketField]int{}
-	for i, value := range t.Values {
-		isValid := false
-
-		for _, field := range fields {
-			if _, ok := takenBy[field]; ok {
-				// field already used
-				continue
-			}
+	for _, value := range t.Values {
+		if !t.matchesAny(value, fields) {
+			invalidValues = append(invalidValues, value)
+		}
+	}
 
-			for _, fieldRange := range field.Ranges {
-				if fieldRange.Contains(value) {
-					takenBy[field] = i
-					isValid = true
-					break
-				}
-			}
+	return invalidValues
+}
 
-			if isValid {
-				break
+// matchesAny returns true if the value is within the ranges of at least one field.
+func (t *RawTicket) matchesAny(value int, fields []*TicketField) bool {
+	for _, field := range fields {
+		for _, fieldRange := range field.Ranges {
+			if fieldRange.Contains(value) {
+				return true
 			}
 		}
-
-		if !isValid {
-			invalidValues = append(invalidValues, value)
-		}
 	}
 
-	return invalidValues
+	return false
 }
 
 type PotentialPositions map[*TicketField][]int
@@ -0,0 +1,65 @@
+package day19
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+func init() {
+	solutions.Register(2020, 19, &Solution{}, solutions.Metadata{
+		Title:   "Monster Messages",
+		Tags:    []string{solutions.StubTag},
+		Runtime: solutions.Fast,
+	})
+}
+
+type Solution struct{}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
+
+	values, err := s.getValues(scanner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get problem values (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return s.part1(values) },
+		func(context.Context) solutions.Part { return s.part2(values) },
+	}, nil
+}
+
+func (s *Solution) getValues(scanner input.Scanner) (values []ProblemValue, err error) {
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		var v ProblemValue
+		if err := v.Unmarshal(line); err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func (s *Solution) part1(values []ProblemValue) solutions.Part {
+	// TODO
+	return solutions.Part{Err: solutions.ErrNotImplemented}
+}
+
+func (s *Solution) part2(values []ProblemValue) solutions.Part {
+	// TODO
+	return solutions.Part{Err: solutions.ErrNotImplemented}
+}
@@ -0,0 +1,83 @@
+package day2
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+func init() {
+	solutions.Register(2020, 2, &Solution{}, solutions.Metadata{
+		Title:   "Password Philosophy",
+		Tags:    []string{"parsing", "validation"},
+		Runtime: solutions.Fast,
+	})
+}
+
+type Solution struct{}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
+
+	lines, err := input.ReadLines(scanner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input (%w)", err)
+	}
+
+	// each part interprets the policies differently, so parse them both ways up front
+	oldEntries, err := s.getEntries(lines, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse entries (%w)", err)
+	}
+
+	entries, err := s.getEntries(lines, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse entries (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return s.part1(oldEntries) },
+		func(context.Context) solutions.Part { return s.part2(entries) },
+	}, nil
+}
+
+// getEntries parses the given lines, returning an input.LineError for the first one that
+// can't be parsed.
+func (s *Solution) getEntries(lines []string, oldPolicy bool) (entries []*PasswordEntry, err error) {
+	for i, line := range lines {
+		entry, err := UnmarshalEntry(line, oldPolicy)
+		if err != nil {
+			return nil, &input.LineError{Line: i + 1, Err: fmt.Errorf("%w (%q)", err, line)}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (s *Solution) part1(entries []*PasswordEntry) solutions.Part {
+	validCount := 0
+	for _, entry := range entries {
+		if entry.IsValid() {
+			validCount++
+		}
+	}
+
+	return solutions.Part{Answer: validCount, Label: "Valid lines (old policy)"}
+}
+
+func (s *Solution) part2(entries []*PasswordEntry) solutions.Part {
+	validCount := 0
+	for _, entry := range entries {
+		if entry.IsValid() {
+			validCount++
+		}
+	}
+
+	return solutions.Part{Answer: validCount, Label: "Valid lines (current policy)"}
+}
@@ -0,0 +1,77 @@
+package day3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+func init() {
+	solutions.Register(2020, 3, &Solution{}, solutions.Metadata{
+		Title:   "Toboggan Trajectory",
+		Tags:    []string{"grid"},
+		Runtime: solutions.Fast,
+	})
+}
+
+type Solution struct{}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	slopes := []Position{
+		{X: 1, Y: 1},
+		{X: 3, Y: 1}, // part 1
+		{X: 5, Y: 1},
+		{X: 7, Y: 1},
+		{X: 1, Y: 2},
+	}
+
+	navMap, err := s.getMap(input.NewReaderScanner(ctx, r))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get map from input (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return s.run(navMap, slopes[1]) },
+		func(context.Context) solutions.Part { return s.run(navMap, slopes...) },
+	}, nil
+}
+
+func (s *Solution) getMap(scanner input.Scanner) (navMap *Map, err error) {
+	defer scanner.Close()
+
+	var rows []Row
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		var row Row
+		if err := row.UnmarshalPattern(line); err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &Map{Rows: rows}, nil
+}
+
+func (s *Solution) run(navMap *Map, slopes ...Position) solutions.Part {
+	var diagnostics []string
+
+	hitsProduct := int64(1)
+	for _, slope := range slopes {
+		hits := navMap.CountHits(slope.X, slope.Y)
+		hitsProduct *= int64(hits)
+
+		diagnostics = append(diagnostics, fmt.Sprintf("With slope %+v, hit %d trees", slope, hits))
+	}
+
+	return solutions.Part{Answer: hitsProduct, Label: "Product of all hits", Diagnostics: diagnostics}
+}
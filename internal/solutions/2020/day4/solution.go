@@ -0,0 +1,82 @@
+package day4
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+func init() {
+	solutions.Register(2020, 4, &Solution{}, solutions.Metadata{
+		Title:   "Passport Processing",
+		Tags:    []string{"parsing", "validation"},
+		Runtime: solutions.Fast,
+	})
+}
+
+type Solution struct{}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	passports, err := s.getPassports(input.NewReaderScanner(ctx, r))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse passports (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return s.run(passports, false) },
+		func(context.Context) solutions.Part { return s.run(passports, true) },
+	}, nil
+}
+
+// getPassports reads all lines from the scanner, unmarshaling each one into the current
+// passport & starting a new one when end-of-passport is reached. Errors are returned as
+// an input.LineError.
+func (s *Solution) getPassports(scanner input.Scanner) (passports []Passport, err error) {
+	defer scanner.Close()
+
+	passport := Passport{}
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) > 0 {
+			if err := passport.Unmarshal([]string{line}); err != nil {
+				return nil, &input.LineError{Line: lineNum, Err: err}
+			}
+
+			continue
+		}
+
+		// reached end of passport
+		passports = append(passports, passport)
+		passport = Passport{} // reset for next passport
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("file read error (%w)", err)
+	}
+
+	if len(passport) > 0 {
+		passports = append(passports, passport)
+	}
+
+	return passports, nil
+}
+
+func (s *Solution) run(passports []Passport, checkValues bool) solutions.Part {
+	validCount := 0
+	for _, passport := range passports {
+		if passport.IsValid(checkValues) {
+			validCount++
+		}
+	}
+
+	label := "Valid passports"
+	if checkValues {
+		label += " (with value checks)"
+	}
+
+	return solutions.Part{Answer: validCount, Label: label}
+}
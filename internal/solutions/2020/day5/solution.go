@@ -0,0 +1,83 @@
+package day5
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+func init() {
+	solutions.Register(2020, 5, &Solution{}, solutions.Metadata{
+		Title:   "Binary Boarding",
+		Tags:    []string{"binary"},
+		Runtime: solutions.Fast,
+	})
+}
+
+type Solution struct{}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	seatIDs, err := s.getSeats(input.NewReaderScanner(ctx, r))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse seats (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return s.part1(seatIDs...) },
+		func(context.Context) solutions.Part { return s.part2(seatIDs...) },
+	}, nil
+}
+
+func (s *Solution) getSeats(scanner input.Scanner) (seatIDs []uint16, err error) {
+	defer scanner.Close()
+
+	// read all lines, keeping track of the highest seat ID along the way
+	for scanner.Scan() {
+		encodedSeat := strings.TrimSpace(scanner.Text())
+
+		var seat Seat
+		if err := seat.Unmarshal(encodedSeat); err != nil {
+			return nil, err
+		}
+
+		seatIDs = append(seatIDs, seat.ID())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return seatIDs, nil
+}
+
+func (s *Solution) part1(seatIDs ...uint16) solutions.Part {
+	maxID := uint16(0)
+	for _, id := range seatIDs {
+		if id > maxID {
+			maxID = id
+		}
+	}
+
+	return solutions.Part{Answer: maxID, Label: "Highest seat ID"}
+}
+
+func (s *Solution) part2(seatIDs ...uint16) solutions.Part {
+	// sort a copy of the seat IDs so we can identify a gap
+	seatIDs = append([]uint16(nil), seatIDs...)
+	sort.Slice(seatIDs, func(i, j int) bool { return seatIDs[i] < seatIDs[j] })
+
+	myID := uint16(0)
+	for i := 0; i < len(seatIDs)-1; i++ {
+		if diff := seatIDs[i+1] - seatIDs[i]; diff > 1 {
+			myID = seatIDs[i] + 1
+			break // no other gaps are allowed by the problem statement
+		}
+	}
+
+	return solutions.Part{Answer: myID, Label: "My seat ID"}
+}
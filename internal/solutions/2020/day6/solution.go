@@ -0,0 +1,85 @@
+package day6
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+func init() {
+	solutions.Register(2020, 6, &Solution{}, solutions.Metadata{
+		Title:   "Custom Customs",
+		Tags:    []string{"sets"},
+		Runtime: solutions.Fast,
+	})
+}
+
+type Solution struct{}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	scanner := input.NewReaderScanner(ctx, r)
+	defer scanner.Close()
+
+	lines, err := input.ReadLines(scanner)
+	if err != nil {
+		return nil, fmt.Errorf("file read error (%w)", err)
+	}
+
+	// responses are only tallied when solving each part, but validate them up front
+	for i, line := range lines {
+		if err := NewUnanimousResponses().UnmarshalNew(line); err != nil {
+			return nil, &input.LineError{Line: i + 1, Err: fmt.Errorf("failed to unmarshal response: %w (line = %q)", err, line)}
+		}
+	}
+
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return s.part1(lines) },
+		func(context.Context) solutions.Part { return s.part2(lines) },
+	}, nil
+}
+
+// countAffirmatives tallies up the yes count of each group in the given lines, using
+// newGroup to create the Responses object for each group.
+func (s *Solution) countAffirmatives(lines []string, newGroup func() Responses) (yesCount int, err error) {
+	group := newGroup()
+
+	for _, line := range lines {
+		if len(line) == 0 {
+			// hit end of group, tally up the group's responses & reset for next one
+			yesCount += group.YesCount()
+			group = newGroup()
+
+			continue
+		}
+
+		if err := group.UnmarshalNew(line); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal response: %w (line = %q)", err, line)
+		}
+	}
+
+	// handle case where input ends without an additional newline
+	yesCount += group.YesCount()
+
+	return yesCount, nil
+}
+
+func (s *Solution) part1(lines []string) solutions.Part {
+	yesCount, err := s.countAffirmatives(lines, NewIndividualResponses)
+	if err != nil {
+		return solutions.Part{Err: err}
+	}
+
+	return solutions.Part{Answer: yesCount, Label: "Affirmatives across all groups"}
+}
+
+func (s *Solution) part2(lines []string) solutions.Part {
+	yesCount, err := s.countAffirmatives(lines, NewUnanimousResponses)
+	if err != nil {
+		return solutions.Part{Err: err}
+	}
+
+	return solutions.Part{Answer: yesCount, Label: "Unanimous affirmatives across all groups"}
+}
@@ -0,0 +1,86 @@
+package day7
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+var (
+	ErrBagNotFound = errors.New("failed to find bag")
+)
+
+func init() {
+	solutions.Register(2020, 7, &Solution{}, solutions.Metadata{
+		Title:   "Handy Haversacks",
+		Tags:    []string{"graph", "recursion"},
+		Runtime: solutions.Moderate,
+	})
+}
+
+type Solution struct{}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	bags, err := s.getBags(input.NewReaderScanner(ctx, r))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bags (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(context.Context) solutions.Part { return part1("shiny gold", bags) },
+		func(context.Context) solutions.Part { return part2("shiny gold", bags) },
+	}, nil
+}
+
+func (s *Solution) getBags(scanner input.Scanner) (bags map[string]Bag, err error) {
+	defer scanner.Close()
+
+	bags = map[string]Bag{}
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		newBag := &bag{}
+		if err := newBag.Unmarshal(line); err != nil {
+			return nil, err
+		}
+
+		bags[newBag.Colour()] = newBag
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return bags, nil
+}
+
+func part1(colour string, bags map[string]Bag) solutions.Part {
+	count := 0
+	for _, bag := range bags {
+		if bag.Contains(colour, bags) {
+			count++
+		}
+	}
+
+	return solutions.Part{Answer: count, Label: fmt.Sprintf("Bags that can contain a %s bag", colour)}
+}
+
+func part2(colour string, bags map[string]Bag) solutions.Part {
+	bag, ok := bags[colour]
+	if !ok {
+		return solutions.Part{Err: fmt.Errorf("%w (%s)", ErrBagNotFound, colour)}
+	}
+
+	count := int64(0)
+	subBags := bag.SubBags(bags)
+	for _, subBagCount := range subBags {
+		count += subBagCount
+	}
+
+	return solutions.Part{Answer: count, Label: fmt.Sprintf("Total bags inside the %s bag", colour)}
+}
@@ -31,6 +31,18 @@ func (s instructionSet) Execute() (accumulator int, sequence []int, recursionDet
 	return accumulator, sequence, recursionDetected
 }
 
+// Clone returns a deep copy of this instruction set, so that it can be executed & modified
+// without affecting the original.
+func (s instructionSet) Clone() instructionSet {
+	clone := make(instructionSet, len(s))
+	for i, instruction := range s {
+		copied := *instruction
+		clone[i] = &copied
+	}
+
+	return clone
+}
+
 // Reset hit indicators for all instructions.
 func (s instructionSet) Reset() {
 	for _, instruction := range s {
@@ -0,0 +1,130 @@
+package day8
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+var (
+	ErrNoFixFound = errors.New("failed to find any instructions where flipping jmp<->nop removed the infinite recursion")
+)
+
+func init() {
+	solutions.Register(2020, 8, &Solution{}, solutions.Metadata{
+		Title:   "Handheld Halting",
+		Tags:    []string{"interpreter"},
+		Runtime: solutions.Fast,
+	})
+}
+
+type Solution struct{}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	instructions, err := s.getInstructions(input.NewReaderScanner(ctx, r))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse instructions (%w)", err)
+	}
+
+	// executing the instructions marks them as hit & part 2 flips them, so each part works
+	// on its own copy
+	return solutions.Parts{
+		func(context.Context) solutions.Part {
+			result, _ := part1(instructions.Clone())
+			return result
+		},
+		func(ctx context.Context) solutions.Part {
+			// part 2 starts from the sequence executed in part 1
+			instructions := instructions.Clone()
+			_, sequence := part1(instructions)
+			return part2(ctx, instructions, sequence)
+		},
+	}, nil
+}
+
+func (s *Solution) getInstructions(scanner input.Scanner) (instructions instructionSet, err error) {
+	defer scanner.Close()
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		var newInstruction instruction
+		if err := newInstruction.Unmarshal(line); err != nil {
+			return nil, err
+		}
+
+		instructions = append(instructions, &newInstruction)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return instructions, nil
+}
+
+func part1(instructions instructionSet) (result solutions.Part, sequence []int) {
+	instructions.Reset()
+	accumulator, sequence, recursionDetected := instructions.Execute()
+	if recursionDetected {
+		result.Diagnostics = append(result.Diagnostics, fmt.Sprintf("Detected recursion (last position: %d)", sequence[len(sequence)-1]))
+	}
+
+	result.Answer = accumulator
+	result.Label = "Accumulator"
+
+	return result, sequence
+}
+
+func tryFixAt(instructions instructionSet, fixPosition int) (accumulator int, ok bool) {
+	instruction := instructions[fixPosition]
+
+	previousOp := instruction.operation
+	defer func() {
+		// revert fix before returning in case this isn't "the one"
+		instruction.operation = previousOp
+	}()
+
+	switch instruction.operation {
+	case jmp:
+		instruction.operation = nop
+
+	case nop:
+		if instruction.argument == 1 {
+			return 0, false // nop +1 and jmp +1 are equivalent here since both jump exactly 1 instruction forward
+		}
+		instruction.operation = jmp
+
+	case acc:
+		return 0, false // acc can't change the outcome here
+	}
+
+	// get result
+	accumulator, _, recursionDetected := instructions.Execute()
+	if recursionDetected {
+		return 0, false // try again with the previous instruction in the sequence
+	}
+
+	return accumulator, true
+}
+
+func part2(ctx context.Context, instructions instructionSet, part1Sequence []int) solutions.Part {
+	// start at the end of the previous sequence and try flipping nop<->jmp until a working path is found
+	for s := len(part1Sequence) - 1; s >= 0; s-- {
+		if err := solutions.CheckContext(ctx); err != nil {
+			return solutions.Part{Err: err}
+		}
+
+		instructions.Reset()
+		if accumulator, ok := tryFixAt(instructions, part1Sequence[s]); ok {
+			return solutions.Part{Answer: accumulator, Label: "Accumulator after fix"}
+		}
+	}
+
+	return solutions.Part{Err: ErrNoFixFound}
+}
@@ -0,0 +1,39 @@
+package day8
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// the parts share the parsed instructions, so they must be solvable in any order, even
+// concurrently (run with -race to catch shared state)
+func TestPartsAreIndependent(t *testing.T) {
+	t.Parallel()
+
+	program := strings.Join([]string{"nop +0", "acc +1", "jmp +4", "acc +3", "jmp -3", "acc -99", "acc +1", "jmp -4", "acc +6"}, "\n")
+
+	parts, err := (&Solution{}).Parse(context.Background(), strings.NewReader(program))
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	expected := []int{5, 8}
+
+	var wg sync.WaitGroup
+	for run := 0; run < 10; run++ {
+		for i := range parts {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				if got := parts[i](context.Background()); got.Err != nil || got.Answer != expected[i] {
+					t.Errorf("Got %v (%v) for part %d, expected %v", got.Answer, got.Err, i+1, expected[i])
+				}
+			}(i)
+		}
+	}
+
+	wg.Wait()
+}
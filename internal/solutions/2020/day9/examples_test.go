@@ -0,0 +1,14 @@
+package day9
+
+import (
+	"testing"
+
+	"github.com/segwin/adventofcode-2020/internal/solutions/solutionstest"
+)
+
+func TestExamples(t *testing.T) {
+	t.Parallel()
+
+	// the examples use a preamble of 5 values instead of 25
+	solutionstest.RunSolutionExamples(t, 2020, 9, &Solution{Preamble: 5})
+}
@@ -0,0 +1,186 @@
+package day9
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+var (
+	ErrNonXMASValueNotFound = errors.New("failed to find non-XMAS-compliant value")
+	ErrWeaknessNotFound     = errors.New("failed to find encryption weakness")
+)
+
+func init() {
+	solutions.Register(2020, 9, &Solution{}, solutions.Metadata{
+		Title:   "Encoding Error",
+		Tags:    []string{"ring-buffer", "brute-force"},
+		Runtime: solutions.Moderate,
+	})
+}
+
+// defaultPreamble is the preamble length used by the puzzle input.
+const defaultPreamble = 25
+
+type Solution struct {
+	// Preamble is the number of values each value is checked against, or 0 to use the
+	// puzzle input's (25). The examples use a shorter one.
+	Preamble int
+}
+
+func (s *Solution) Parse(ctx context.Context, r io.Reader) (solutions.Parts, error) {
+	values, err := s.getValues(input.NewReaderScanner(ctx, r))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get values (%w)", err)
+	}
+
+	return solutions.Parts{
+		func(context.Context) solutions.Part {
+			invalidValue, err := s.part1(values)
+			if err != nil {
+				return solutions.Part{Err: err}
+			}
+
+			return solutions.Part{Answer: invalidValue, Label: "First value that doesn't follow XMAS"}
+		},
+		func(context.Context) solutions.Part {
+			// part 2 looks for the invalid value found in part 1
+			invalidValue, err := s.part1(values)
+			if err != nil {
+				return solutions.Part{Err: err}
+			}
+
+			weakness, err := s.part2(values, invalidValue)
+			if err != nil {
+				return solutions.Part{Err: err}
+			}
+
+			return solutions.Part{Answer: weakness, Label: "Encryption weakness"}
+		},
+	}, nil
+}
+
+func (s *Solution) getValues(scanner input.Scanner) (values []int, err error) {
+	defer scanner.Close()
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		value, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func (s *Solution) part1(values []int) (invalidValue int, err error) {
+	preamble := s.Preamble
+	if preamble == 0 {
+		preamble = defaultPreamble
+	}
+
+	previous := NewIntRing(preamble)
+	for _, value := range values {
+		if previous.Len() == preamble {
+			if !s.followsXMAS(value, previous) {
+				return value, nil
+			}
+		}
+
+		// add to previous values & continue with next line
+		if err := previous.Push(value); err != nil {
+			return 0, err
+		}
+	}
+
+	return 0, ErrNonXMASValueNotFound
+}
+
+func (s *Solution) part2(values []int, invalidValue int) (weakness int, err error) {
+	for offset := range values {
+		sum := 0
+		var positions []int
+
+		reset := func() {
+			sum = 0
+			positions = nil
+		}
+
+		for i := offset; i < len(values); i++ {
+			// special case: skip the invalid value itself
+			if values[i] == invalidValue {
+				reset()
+				continue
+			}
+
+			sum += values[i]
+			positions = append(positions, i)
+
+			if sum == invalidValue {
+				min := math.MaxInt32
+				max := math.MinInt32
+
+				for _, position := range positions {
+					if values[position] < min {
+						min = values[position]
+					}
+					if values[position] > max {
+						max = values[position]
+					}
+				}
+
+				return min + max, nil
+			}
+
+			if sum > invalidValue {
+				reset() // this sum didn't work out, reset & try with next position
+			}
+		}
+	}
+
+	return 0, ErrWeaknessNotFound
+}
+
+func (s *Solution) followsXMAS(value int, previous Ring) bool {
+	for i := 0; i < previous.Len(); i++ {
+		last1 := s.getAt(i, previous)
+
+		for j := 0; j < previous.Len(); j++ {
+			if j == i {
+				continue
+			}
+
+			last2 := s.getAt(j, previous)
+			if last1+last2 == value {
+				// ok
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s *Solution) getAt(i int, previous Ring) int {
+	value, ok := previous.MustGet(i).(int)
+	if !ok {
+		// only ints are ever pushed to the ring, so this can't happen
+		panic(fmt.Errorf("%w (got %T in previous ring)", ErrInvalidRingValue, previous.MustGet(i)))
+	}
+
+	return value
+}
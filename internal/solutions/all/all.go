@@ -0,0 +1,31 @@
+// Package all registers every solution with the solutions registry. It is meant to be
+// imported for its side effects only.
+package all
+
+import (
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day1"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day10"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day11"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day12"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day13"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day14"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day15"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day16"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day17"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day18"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day19"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day2"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day20"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day21"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day22"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day23"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day24"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day25"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day3"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day4"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day5"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day6"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day7"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day8"
+	_ "github.com/segwin/adventofcode-2020/internal/solutions/2020/day9"
+)
@@ -0,0 +1,129 @@
+package solutions
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// RuntimeClass gives a rough idea of how long a solution takes to run.
+type RuntimeClass int
+
+const (
+	Fast     RuntimeClass = iota // under 10ms
+	Moderate                     // under 1s
+	Slow                         // 1s or more
+)
+
+func (c RuntimeClass) String() string {
+	switch c {
+	case Fast:
+		return "fast"
+	case Moderate:
+		return "moderate"
+	case Slow:
+		return "slow"
+	}
+
+	return "<unknown>"
+}
+
+// StubTag is the tag given to placeholder solutions that don't solve anything yet.
+const StubTag = "stub"
+
+// Metadata describes a registered solution.
+type Metadata struct {
+	// Title is the puzzle's title.
+	Title string
+
+	// Tags are free-form keywords describing the puzzle or its solution.
+	Tags []string
+
+	// Runtime is the expected runtime class of the solution.
+	Runtime RuntimeClass
+}
+
+// HasTag returns true if this metadata contains the given tag.
+func (m Metadata) HasTag(tag string) bool {
+	for _, t := range m.Tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Entry is a solution registered for a given puzzle.
+type Entry struct {
+	Year     int
+	Day      int
+	Solution Solution
+	Metadata Metadata
+}
+
+type puzzle struct {
+	year int
+	day  int
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[puzzle]Entry{}
+)
+
+// Register makes a solution available for the given puzzle. It is meant to be called
+// from the init function of each solution package, and panics if a solution is
+// registered twice for the same puzzle.
+func Register(year, day int, solution Solution, metadata Metadata) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	key := puzzle{year: year, day: day}
+	if _, ok := registry[key]; ok {
+		panic(fmt.Sprintf("solution registered twice for %d day %d", year, day))
+	}
+
+	registry[key] = Entry{Year: year, Day: day, Solution: solution, Metadata: metadata}
+}
+
+// Get returns the solution registered for the given puzzle, if any.
+func Get(year, day int) (entry Entry, ok bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	entry, ok = registry[puzzle{year: year, day: day}]
+	return entry, ok
+}
+
+// List returns all solutions registered for the given year, sorted by day.
+func List(year int) (entries []Entry) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for key, entry := range registry {
+		if key.year == year {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Day < entries[j].Day })
+	return entries
+}
+
+// Years returns all years with at least one registered solution, in ascending order.
+func Years() (years []int) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	seen := map[int]bool{}
+	for key := range registry {
+		if !seen[key.year] {
+			seen[key.year] = true
+			years = append(years, key.year)
+		}
+	}
+
+	sort.Ints(years)
+	return years
+}
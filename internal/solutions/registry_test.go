@@ -0,0 +1,55 @@
+package solutions
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeSolution struct{ id int }
+
+func (s *fakeSolution) Parse(ctx context.Context, r io.Reader) (Parts, error) {
+	return nil, nil
+}
+
+func TestRegistry(t *testing.T) {
+	Register(1999, 2, &fakeSolution{id: 2}, Metadata{Title: "two"})
+	Register(1999, 1, &fakeSolution{id: 1}, Metadata{Title: "one", Tags: []string{StubTag}})
+	Register(1998, 1, &fakeSolution{id: 3}, Metadata{Title: "other year"})
+
+	expected := []Entry{
+		{Year: 1999, Day: 1, Solution: &fakeSolution{id: 1}, Metadata: Metadata{Title: "one", Tags: []string{StubTag}}},
+		{Year: 1999, Day: 2, Solution: &fakeSolution{id: 2}, Metadata: Metadata{Title: "two"}},
+	}
+
+	if diff := cmp.Diff(expected, List(1999), cmp.AllowUnexported(fakeSolution{})); diff != "" {
+		t.Errorf("Unexpected diff:\n%v", diff)
+	}
+
+	if diff := cmp.Diff([]int{1998, 1999}, Years()); diff != "" {
+		t.Errorf("Unexpected diff:\n%v", diff)
+	}
+
+	entry, ok := Get(1999, 1)
+	if !ok {
+		t.Fatal("Got false, expected true")
+	}
+
+	if got, expected := entry.Metadata.HasTag(StubTag), true; got != expected {
+		t.Errorf("Got %v, expected %v", got, expected)
+	}
+
+	if _, ok := Get(1999, 3); ok {
+		t.Error("Got true, expected false")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic on duplicate registration")
+		}
+	}()
+
+	Register(1999, 1, &fakeSolution{}, Metadata{})
+}
@@ -1,8 +1,167 @@
 package solutions
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/logging"
+)
+
+var (
+	ErrNotImplemented = errors.New("not implemented")
+	ErrTimeout        = errors.New("solution timed out")
+	ErrInvalidPart    = errors.New("invalid part")
+	ErrPanicked       = errors.New("solution panicked")
+)
 
 // Solution is the interface implemented by all solutions for any given day.
 type Solution interface {
-	Run(ctx context.Context, inputFile string)
+	// Parse reads & parses the problem input from r, returning the entry points
+	// solving each part of the problem from the parsed input. An error is returned if
+	// the input could not be processed at all.
+	Parse(ctx context.Context, r io.Reader) (Parts, error)
+}
+
+// PartFunc solves a single part of a problem. Any error, including interruptions
+// (see CheckContext), is reported in the returned Part. A PartFunc doesn't modify
+// the parsed input it shares with other parts, so it may be called any number of
+// times.
+type PartFunc func(ctx context.Context) Part
+
+// Parts holds the entry points for each part of a problem, in part order.
+type Parts []PartFunc
+
+// Solve parses the input read from r using the given solution, then solves the
+// given parts (numbered from 1) or all parts if none are given. A panic while parsing
+// or solving a part is recovered and reported as an error wrapping ErrPanicked, so one
+// broken solution can't take down its caller. Any StageHook carried by ctx is called
+// around each stage (see WithStageHook).
+func Solve(ctx context.Context, solution Solution, r io.Reader, parts ...int) (Result, error) {
+	hook := stageHookFrom(ctx)
+
+	done := hook("parse")
+	partFuncs, err := Parse(ctx, solution, r)
+	done()
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	if len(parts) == 0 {
+		for i := range partFuncs {
+			parts = append(parts, i+1)
+		}
+	}
+
+	var result Result
+	for _, number := range parts {
+		if number < 1 || number > len(partFuncs) {
+			return Result{}, fmt.Errorf("%w (%d, expected 1 to %d)", ErrInvalidPart, number, len(partFuncs))
+		}
+
+		done := hook(fmt.Sprintf("part%d", number))
+		start := time.Now()
+		part := SolvePart(ctx, partFuncs[number-1])
+		part.Duration = time.Since(start)
+		done()
+
+		part.Number = number
+
+		logger := logging.FromContext(ctx)
+		for _, diagnostic := range part.Diagnostics {
+			logger.Debugf("Part %d: %s", number, diagnostic)
+		}
+
+		result.Parts = append(result.Parts, part)
+	}
+
+	return result, nil
+}
+
+// StageHook is called by Solve when it starts a stage of solving a problem: "parse",
+// then "part<N>" for each part solved. It returns a function called once that stage is
+// done.
+type StageHook func(stage string) (done func())
+
+type stageHookKey struct{}
+
+// WithStageHook returns a copy of ctx carrying the given hook, e.g. to profile each
+// stage of Solve separately.
+func WithStageHook(ctx context.Context, hook StageHook) context.Context {
+	return context.WithValue(ctx, stageHookKey{}, hook)
+}
+
+func stageHookFrom(ctx context.Context) StageHook {
+	if hook, ok := ctx.Value(stageHookKey{}).(StageHook); ok {
+		return hook
+	}
+
+	return func(string) func() { return func() {} }
+}
+
+// Parse parses the input read from r with the given solution, reporting a panic as an
+// error wrapping ErrPanicked.
+func Parse(ctx context.Context, solution Solution, r io.Reader) (parts Parts, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("%w while parsing (%v)", ErrPanicked, p)
+		}
+	}()
+
+	return solution.Parse(ctx, r)
+}
+
+// SolvePart solves a single part, reporting a panic as a Part whose Err wraps ErrPanicked.
+func SolvePart(ctx context.Context, partFunc PartFunc) (part Part) {
+	defer func() {
+		if p := recover(); p != nil {
+			part = Part{Err: fmt.Errorf("%w (%v)", ErrPanicked, p)}
+		}
+	}()
+
+	return partFunc(ctx)
+}
+
+// Result holds the answers computed by a Solution, in part order.
+type Result struct {
+	Parts []Part
+}
+
+// Part holds the outcome of solving a single part of a problem.
+type Part struct {
+	// Number identifies this part within the problem, starting at 1.
+	Number int
+
+	// Answer is the value computed for this part (e.g. an int, int64 or *big.Int).
+	// It is nil if Err is set.
+	Answer interface{}
+
+	// Label is a short human-readable description of the answer.
+	Label string
+
+	// Diagnostics holds any additional information gathered while solving this
+	// part, such as intermediate values. Solve also logs them at debug level.
+	Diagnostics []string
+
+	// Err is set if this part could not be solved.
+	Err error
+
+	// Duration is the time taken to solve this part, excluding parsing. It is set by Solve.
+	Duration time.Duration
+}
+
+// CheckContext returns nil if ctx isn't done yet. Otherwise, it returns an error
+// wrapping ErrTimeout if ctx's deadline has passed, or ctx's error if it was canceled.
+func CheckContext(ctx context.Context) error {
+	switch err := ctx.Err(); {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w (%v)", ErrTimeout, err)
+	default:
+		return err
+	}
 }
@@ -0,0 +1,231 @@
+package solutions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segwin/adventofcode-2020/internal/logging"
+)
+
+func TestCheckContext(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		ctx func() (context.Context, context.CancelFunc)
+
+		// outputs
+		expectedErr error
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		ctx, cancel := cfg.ctx()
+		defer cancel()
+
+		if err := CheckContext(ctx); !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+	}
+
+	tests := map[string]Test{
+		"ok: context not done": {
+			ctx:         func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			expectedErr: nil,
+		},
+
+		"error: deadline exceeded": {
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+			},
+			expectedErr: ErrTimeout,
+		},
+
+		"error: canceled": {
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			expectedErr: context.Canceled,
+		},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+type partsSolution struct{}
+
+func (s *partsSolution) Parse(ctx context.Context, r io.Reader) (Parts, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parts{
+		func(context.Context) Part { return Part{Answer: string(data) + "1"} },
+		func(context.Context) Part { return Part{Answer: string(data) + "2", Diagnostics: []string{"second part"}} },
+	}, nil
+}
+
+func TestSolve(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		parts []int
+
+		// outputs
+		expected    Result
+		expectedErr error
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		got, err := Solve(context.Background(), &partsSolution{}, strings.NewReader("part"), cfg.parts...)
+		if !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+
+		// durations vary from one run to the next
+		for i := range got.Parts {
+			got.Parts[i].Duration = 0
+		}
+
+		if diff := cmp.Diff(cfg.expected, got); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+	}
+
+	tests := map[string]Test{
+		"ok: all parts": {
+			parts:    nil,
+			expected: Result{Parts: []Part{{Number: 1, Answer: "part1"}, {Number: 2, Answer: "part2", Diagnostics: []string{"second part"}}}},
+		},
+
+		"ok: single part": {
+			parts:    []int{2},
+			expected: Result{Parts: []Part{{Number: 2, Answer: "part2", Diagnostics: []string{"second part"}}}},
+		},
+
+		"error: unknown part": {
+			parts:       []int{3},
+			expectedErr: ErrInvalidPart,
+		},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+func TestSolveStageHook(t *testing.T) {
+	t.Parallel()
+
+	var stages []string
+	ctx := WithStageHook(context.Background(), func(stage string) func() {
+		stages = append(stages, stage)
+		return func() { stages = append(stages, stage+" done") }
+	})
+
+	if _, err := Solve(ctx, &partsSolution{}, strings.NewReader("part"), 2); err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	expected := []string{"parse", "parse done", "part2", "part2 done"}
+	if diff := cmp.Diff(expected, stages); diff != "" {
+		t.Errorf("Unexpected diff:\n%v", diff)
+	}
+}
+
+func TestSolveDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	var log bytes.Buffer
+	ctx := logging.WithLogger(context.Background(), logging.New(&log, logging.Debug))
+
+	if _, err := Solve(ctx, &partsSolution{}, strings.NewReader("part")); err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	if expected := "DEBUG Part 2: second part\n"; log.String() != expected {
+		t.Errorf("Got %q, expected %q", log.String(), expected)
+	}
+}
+
+// panicSolution panics while parsing if its input is "parse", otherwise its second
+// part panics.
+type panicSolution struct{}
+
+func (s *panicSolution) Parse(ctx context.Context, r io.Reader) (Parts, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	} else if string(data) == "parse" {
+		panic("unexpected input")
+	}
+
+	return Parts{
+		func(context.Context) Part { return Part{Answer: 1} },
+		func(context.Context) Part { return Part{Answer: []int{}[1]} },
+	}, nil
+}
+
+func TestSolvePanic(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		input string
+
+		// outputs
+		expectedPartErrs []error
+		expectedErr      error
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		got, err := Solve(context.Background(), &panicSolution{}, strings.NewReader(cfg.input))
+		if !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+
+		if len(got.Parts) != len(cfg.expectedPartErrs) {
+			t.Fatalf("Got %d parts, expected %d", len(got.Parts), len(cfg.expectedPartErrs))
+		}
+
+		for i, part := range got.Parts {
+			if !errors.Is(part.Err, cfg.expectedPartErrs[i]) {
+				t.Errorf("Part %d: got %v, expected %v", part.Number, part.Err, cfg.expectedPartErrs[i])
+			}
+		}
+	}
+
+	tests := map[string]Test{
+		"error: panic in part": {
+			input:            "solve",
+			expectedPartErrs: []error{nil, ErrPanicked},
+		},
+
+		"error: panic while parsing": {
+			input:       "parse",
+			expectedErr: ErrPanicked,
+		},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
@@ -0,0 +1,120 @@
+// Package solutionstest provides utilities for testing solutions.
+package solutionstest
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/segwin/adventofcode-2020/internal/gomod"
+	"github.com/segwin/adventofcode-2020/internal/input"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+// expectedSuffix is appended to an example's file name to get its expected answers.
+const expectedSuffix = ".expected"
+
+// RunExamples solves each example input found in inputs/<year>/day<N> (named example1,
+// example2, etc.) using the registered solution for the given day, then compares the
+// answers to those in the matching .expected file (e.g. example1.expected). That file
+// holds one answer per line, in part order; parts with an empty line are not solved.
+//
+// The test is skipped if the day has no examples.
+func RunExamples(t *testing.T, year, day int) {
+	t.Helper()
+
+	entry, ok := solutions.Get(year, day)
+	if !ok {
+		t.Fatalf("No solution registered for %d day %d", year, day)
+	}
+
+	RunSolutionExamples(t, year, day, entry.Solution)
+}
+
+// RunSolutionExamples is like RunExamples, but solves the examples with the given solution
+// rather than the registered one, e.g. to configure it for the examples.
+func RunSolutionExamples(t *testing.T, year, day int, solution solutions.Solution) {
+	t.Helper()
+
+	root, err := gomod.Root()
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	dir := filepath.Join(root, "inputs", strconv.Itoa(year), fmt.Sprintf("day%d", day))
+	examples, err := filepath.Glob(filepath.Join(dir, "example*"))
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	ran := false
+	for _, example := range examples {
+		if strings.HasSuffix(example, expectedSuffix) {
+			continue
+		}
+
+		ran = true
+		example := example
+		t.Run(filepath.Base(example), func(t *testing.T) { runExample(t, solution, example) })
+	}
+
+	if !ran {
+		t.Skipf("No examples found in %s", dir)
+	}
+}
+
+func runExample(t *testing.T, solution solutions.Solution, example string) {
+	expected, err := readLines(example + expectedSuffix)
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	// only solve the parts with an expected answer
+	var parts []int
+	for i, answer := range expected {
+		if answer != "" {
+			parts = append(parts, i+1)
+		}
+	}
+
+	if len(parts) == 0 {
+		t.Skipf("No expected answers in %s", example+expectedSuffix)
+	}
+
+	file, err := input.Open(example)
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	defer file.Close()
+
+	result, err := solutions.Solve(context.Background(), solution, file, parts...)
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	for _, part := range result.Parts {
+		if part.Err != nil {
+			t.Errorf("Part %d: got %v, expected nil", part.Number, part.Err)
+			continue
+		}
+
+		if got, expected := fmt.Sprint(part.Answer), expected[part.Number-1]; got != expected {
+			t.Errorf("Part %d: got %v, expected %v", part.Number, got, expected)
+		}
+	}
+}
+
+func readLines(path string) (lines []string, err error) {
+	scanner, err := input.NewFileScanner(context.Background(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer scanner.Close()
+
+	return input.ReadLines(scanner)
+}
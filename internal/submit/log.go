@@ -0,0 +1,153 @@
+package submit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var (
+	ErrAlreadySolved = errors.New("part already solved")
+	ErrDuplicate     = errors.New("answer already submitted")
+	ErrOutOfBounds   = errors.New("answer out of bounds")
+	ErrMustWait      = errors.New("too soon to submit again")
+)
+
+// DefaultLogPath is the default location of the submission log, relative to the working
+// directory.
+const DefaultLogPath = ".aoc/submissions.ndjson"
+
+// Attempt is a submitted answer along with the server's verdict.
+type Attempt struct {
+	Time    time.Time `json:"time"`
+	Year    int       `json:"year"`
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	WaitS   float64   `json:"wait_s,omitempty"`
+}
+
+// Wait returns how long the server asked to wait after this attempt.
+func (a Attempt) Wait() time.Duration {
+	return time.Duration(a.WaitS * float64(time.Second))
+}
+
+// Log is a file holding one JSON attempt per line, oldest first.
+type Log struct {
+	Path string
+}
+
+// Append adds the given attempt to the end of the log, creating it if needed.
+func (l *Log) Append(attempt Attempt) error {
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create submission log directory (%w)", err)
+	}
+
+	data, err := json.Marshal(attempt)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open submission log (%w)", err)
+	}
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write submission log (%w)", err)
+	}
+
+	return file.Close()
+}
+
+// Load returns all attempts for the given puzzle, oldest first. A missing log has no
+// attempts.
+func (l *Log) Load(year, day int) (attempts []Attempt, err error) {
+	file, err := os.Open(l.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open submission log (%w)", err)
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var a Attempt
+		if err := json.Unmarshal(scanner.Bytes(), &a); err != nil {
+			return nil, fmt.Errorf("invalid submission on line %d (%w)", line, err)
+		}
+
+		if a.Year == year && a.Day == day {
+			attempts = append(attempts, a)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// Check returns an error if submitting answer to the given part at the given time is
+// pointless given the past attempts on its puzzle: if the part is already solved, if the
+// same answer was already judged, if a past verdict rules it out (e.g. it's higher than
+// an answer that was too high), or if the server asked to wait longer.
+func Check(attempts []Attempt, part int, answer string, now time.Time) error {
+	value, numeric := new(big.Int).SetString(answer, 10)
+
+	for _, a := range attempts {
+		if a.Part != part {
+			continue
+		}
+
+		when := a.Time.Local().Format("2006-01-02 15:04:05")
+		switch a.Verdict {
+		case Correct:
+			return fmt.Errorf("%w (%q was correct on %s)", ErrAlreadySolved, a.Answer, when)
+		case AlreadySolved:
+			return fmt.Errorf("%w (according to the server on %s)", ErrAlreadySolved, when)
+		case RateLimited, Unknown:
+			continue // not judged
+		}
+
+		if a.Answer == answer {
+			return fmt.Errorf("%w (%q was %s on %s)", ErrDuplicate, answer, a.Verdict, when)
+		}
+
+		previous, ok := new(big.Int).SetString(a.Answer, 10)
+		if !numeric || !ok {
+			continue
+		}
+
+		if a.Verdict == TooHigh && value.Cmp(previous) >= 0 {
+			return fmt.Errorf("%w (%s is at least %s, which was too high)", ErrOutOfBounds, answer, a.Answer)
+		} else if a.Verdict == TooLow && value.Cmp(previous) <= 0 {
+			return fmt.Errorf("%w (%s is at most %s, which was too low)", ErrOutOfBounds, answer, a.Answer)
+		}
+	}
+
+	// the server's wait applies to all parts of all puzzles, but other puzzles aren't
+	// loaded so this is only a best effort
+	for _, a := range attempts {
+		if until := a.Time.Add(a.Wait()); now.Before(until) {
+			return fmt.Errorf("%w (wait %s)", ErrMustWait, until.Sub(now).Round(time.Second))
+		}
+	}
+
+	return nil
+}
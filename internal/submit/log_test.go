@@ -0,0 +1,145 @@
+package submit
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLog(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "aoc-submit-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	log := &Log{Path: filepath.Join(dir, ".aoc", "submissions.ndjson")}
+
+	// a missing log has no attempts
+	if attempts, err := log.Load(2020, 5); err != nil || len(attempts) != 0 {
+		t.Fatalf("Got %v (%v), expected no attempts", attempts, err)
+	}
+
+	attempts := []Attempt{
+		{Time: time.Date(2020, 12, 5, 6, 0, 0, 0, time.UTC), Year: 2020, Day: 5, Part: 1, Answer: "954", Verdict: TooHigh, WaitS: 60},
+		{Time: time.Date(2020, 12, 6, 6, 0, 0, 0, time.UTC), Year: 2020, Day: 6, Part: 1, Answer: "6", Verdict: Correct},
+		{Time: time.Date(2020, 12, 5, 6, 2, 0, 0, time.UTC), Year: 2020, Day: 5, Part: 1, Answer: "953", Verdict: Correct},
+	}
+
+	for _, a := range attempts {
+		if err := log.Append(a); err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+	}
+
+	got, err := log.Load(2020, 5)
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	expected := []Attempt{attempts[0], attempts[2]}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Unexpected diff:\n%v", diff)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		attempts []Attempt
+		part     int
+		answer   string
+
+		// outputs
+		expectedErr error
+	}
+
+	start := time.Date(2020, 12, 5, 6, 0, 0, 0, time.UTC)
+	now := start.Add(10 * time.Minute)
+
+	wrong := []Attempt{
+		{Time: start, Part: 1, Answer: "100", Verdict: TooHigh},
+		{Time: start, Part: 1, Answer: "10", Verdict: TooLow},
+		{Time: start, Part: 1, Answer: "50", Verdict: Wrong},
+		{Time: start, Part: 1, Answer: "60", Verdict: RateLimited},
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		if err := Check(cfg.attempts, cfg.part, cfg.answer, now); !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+	}
+
+	tests := map[string]Test{
+		"ok: no attempts": {
+			part:   1,
+			answer: "42",
+		},
+		"ok: within bounds": {
+			attempts: wrong,
+			part:     1,
+			answer:   "42",
+		},
+		"ok: rate limited answer": {
+			attempts: wrong,
+			part:     1,
+			answer:   "60",
+		},
+		"ok: other part": {
+			attempts: wrong,
+			part:     2,
+			answer:   "100",
+		},
+		"ok: wait is over": {
+			attempts: []Attempt{{Time: start, Part: 1, Answer: "100", Verdict: TooHigh, WaitS: 60}},
+			part:     1,
+			answer:   "42",
+		},
+		"error: duplicate": {
+			attempts:    wrong,
+			part:        1,
+			answer:      "50",
+			expectedErr: ErrDuplicate,
+		},
+		"error: too high": {
+			attempts:    wrong,
+			part:        1,
+			answer:      "101",
+			expectedErr: ErrOutOfBounds,
+		},
+		"error: too low": {
+			attempts:    wrong,
+			part:        1,
+			answer:      "9",
+			expectedErr: ErrOutOfBounds,
+		},
+		"error: already solved": {
+			attempts:    []Attempt{{Time: start, Part: 1, Answer: "42", Verdict: Correct}},
+			part:        1,
+			answer:      "43",
+			expectedErr: ErrAlreadySolved,
+		},
+		"error: must wait": {
+			attempts:    []Attempt{{Time: start, Part: 2, Answer: "1", Verdict: RateLimited, WaitS: 3600}},
+			part:        1,
+			answer:      "42",
+			expectedErr: ErrMustWait,
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
@@ -0,0 +1,167 @@
+// Package submit posts puzzle answers to the Advent of Code website & keeps track of
+// past attempts, so the same wrong answer is never submitted twice.
+package submit
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/input"
+)
+
+const userAgent = "github.com/segwin/adventofcode-2020"
+
+// Verdict is the server's judgement of a submitted answer.
+type Verdict string
+
+const (
+	Correct       Verdict = "correct"
+	TooHigh       Verdict = "too-high"
+	TooLow        Verdict = "too-low"
+	Wrong         Verdict = "wrong" // incorrect, without a hint
+	RateLimited   Verdict = "rate-limited"
+	AlreadySolved Verdict = "already-solved"
+	Unknown       Verdict = "unknown"
+)
+
+// Outcome is the server's response to a submitted answer.
+type Outcome struct {
+	Verdict Verdict
+
+	// Wait is how long the server asks to wait before submitting another answer, if it
+	// says so.
+	Wait time.Duration
+
+	// Message is the text of the server's response.
+	Message string
+}
+
+// Submitter posts answers to a puzzle server.
+type Submitter interface {
+	// Submit posts the answer to the given part of a puzzle, returning the server's
+	// verdict.
+	Submit(ctx context.Context, year, day, part int, answer string) (Outcome, error)
+}
+
+// Config holds the settings used by a Submitter. Zero values are replaced by their
+// defaults.
+type Config struct {
+	// BaseURL is the URL answers are posted to, as <BaseURL>/<year>/day/<day>/answer.
+	BaseURL string
+
+	// Session is the session token used to authenticate with the server.
+	Session string
+
+	// Client is the HTTP client used to make requests.
+	Client *http.Client
+}
+
+type submitter struct {
+	Config
+}
+
+func New(cfg Config) Submitter {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = input.DefaultBaseURL
+	}
+
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+
+	return &submitter{Config: cfg}
+}
+
+func (s *submitter) Submit(ctx context.Context, year, day, part int, answer string) (Outcome, error) {
+	if s.Session == "" {
+		return Outcome{}, input.ErrNoSession
+	}
+
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+
+	u := fmt.Sprintf("%s/%d/day/%d/answer", strings.TrimSuffix(s.BaseURL, "/"), year, day)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Outcome{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", userAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: s.Session})
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("failed to submit answer (%w)", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Outcome{}, fmt.Errorf("%w (%s)", input.ErrBadHTTPResponse, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return Outcome{}, fmt.Errorf("failed to read response (%w)", err)
+	}
+
+	return ParseResponse(string(body)), nil
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	leftPattern    = regexp.MustCompile(`(?:(\d+)m )?(\d+)s left to wait`)
+	minutesPattern = regexp.MustCompile(`wait (one|\d+) minutes? before trying again`)
+)
+
+// ParseResponse extracts the outcome of a submission from the server's HTML response.
+func ParseResponse(body string) Outcome {
+	message := body
+	if match := articlePattern.FindStringSubmatch(body); match != nil {
+		message = match[1]
+	}
+
+	message = html.UnescapeString(tagPattern.ReplaceAllString(message, ""))
+	message = strings.Join(strings.Fields(message), " ")
+
+	outcome := Outcome{Verdict: Unknown, Message: message}
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		outcome.Verdict = Correct
+	case strings.Contains(message, "your answer is too high"):
+		outcome.Verdict = TooHigh
+	case strings.Contains(message, "your answer is too low"):
+		outcome.Verdict = TooLow
+	case strings.Contains(message, "That's not the right answer"):
+		outcome.Verdict = Wrong
+	case strings.Contains(message, "You gave an answer too recently"):
+		outcome.Verdict = RateLimited
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		outcome.Verdict = AlreadySolved
+	}
+
+	if match := leftPattern.FindStringSubmatch(message); match != nil {
+		minutes, _ := strconv.Atoi(match[1]) // empty if under a minute
+		seconds, _ := strconv.Atoi(match[2])
+		outcome.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if match := minutesPattern.FindStringSubmatch(message); match != nil {
+		minutes := 1
+		if match[1] != "one" {
+			minutes, _ = strconv.Atoi(match[1])
+		}
+
+		outcome.Wait = time.Duration(minutes) * time.Minute
+	}
+
+	return outcome
+}
@@ -0,0 +1,153 @@
+package submit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segwin/adventofcode-2020/internal/input"
+)
+
+func TestSubmit(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		session    string
+		statusCode int
+		response   string
+
+		// outputs
+		expected    Outcome
+		expectedErr error
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/2020/day/5/answer" {
+				t.Errorf("Got %s %s, expected POST /2020/day/5/answer", r.Method, r.URL.Path)
+			}
+
+			if cookie, err := r.Cookie("session"); err != nil || cookie.Value != cfg.session {
+				t.Errorf("Got %v (%v), expected session=%s", cookie, err, cfg.session)
+			}
+
+			if level, answer := r.PostFormValue("level"), r.PostFormValue("answer"); level != "2" || answer != "615" {
+				t.Errorf("Got level=%s & answer=%s, expected level=2 & answer=615", level, answer)
+			}
+
+			w.WriteHeader(cfg.statusCode)
+			_, _ = w.Write([]byte(cfg.response))
+		}))
+		defer server.Close()
+
+		submitter := New(Config{BaseURL: server.URL, Session: cfg.session, Client: server.Client()})
+
+		got, err := submitter.Submit(context.Background(), 2020, 5, 2, "615")
+		if !errors.Is(err, cfg.expectedErr) {
+			t.Errorf("Got %v, expected %v", err, cfg.expectedErr)
+		}
+
+		if diff := cmp.Diff(cfg.expected, got); diff != "" {
+			t.Errorf("Unexpected diff:\n%v", diff)
+		}
+	}
+
+	tests := map[string]Test{
+		"ok: correct": {
+			session:    "token",
+			statusCode: http.StatusOK,
+			response:   `<html><main><article><p>That's the right answer!  You are <em>one gold star</em> closer.</p></article></main></html>`,
+			expected:   Outcome{Verdict: Correct, Message: "That's the right answer! You are one gold star closer."},
+		},
+		"error: bad response": {
+			session:     "token",
+			statusCode:  http.StatusBadRequest,
+			expectedErr: input.ErrBadHTTPResponse,
+		},
+		"error: no session": {
+			expectedErr: input.ErrNoSession,
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		body string
+
+		// outputs
+		expectedVerdict Verdict
+		expectedWait    time.Duration
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		got := ParseResponse(cfg.body)
+		if got.Verdict != cfg.expectedVerdict {
+			t.Errorf("Got %v, expected %v", got.Verdict, cfg.expectedVerdict)
+		}
+
+		if got.Wait != cfg.expectedWait {
+			t.Errorf("Got %v, expected %v", got.Wait, cfg.expectedWait)
+		}
+	}
+
+	tests := map[string]Test{
+		"correct": {
+			body:            "<article><p>That's the right answer!</p></article>",
+			expectedVerdict: Correct,
+		},
+		"too high": {
+			body:            "<article><p>That's not the right answer; your answer is too high. Please wait one minute before trying again.</p></article>",
+			expectedVerdict: TooHigh,
+			expectedWait:    time.Minute,
+		},
+		"too low": {
+			body:            "<article><p>That's not the right answer; your answer is too low. Please wait 5 minutes before trying again.</p></article>",
+			expectedVerdict: TooLow,
+			expectedWait:    5 * time.Minute,
+		},
+		"wrong": {
+			body:            "<article><p>That's not the right answer. If you're stuck, ...</p></article>",
+			expectedVerdict: Wrong,
+		},
+		"rate limited": {
+			body:            "<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 32s left to wait.</p></article>",
+			expectedVerdict: RateLimited,
+			expectedWait:    4*time.Minute + 32*time.Second,
+		},
+		"rate limited under a minute": {
+			body:            "<article><p>You gave an answer too recently. You have 35s left to wait.</p></article>",
+			expectedVerdict: RateLimited,
+			expectedWait:    35 * time.Second,
+		},
+		"already solved": {
+			body:            "<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>",
+			expectedVerdict: AlreadySolved,
+		},
+		"unknown": {
+			body:            "<html>Maintenance</html>",
+			expectedVerdict: Unknown,
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
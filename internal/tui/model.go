@@ -0,0 +1,233 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+// Keys understood by the model, as decoded from terminal input.
+const (
+	keyUp       = "up"
+	keyDown     = "down"
+	keyPageUp   = "pgup"
+	keyPageDown = "pgdown"
+	keyEnter    = "enter"
+	keyTab      = "tab"
+	keyEscape   = "esc"
+	keyCtrlC    = "ctrl-c"
+)
+
+const (
+	statusNone    = ""
+	statusRunning = "running"
+	statusOK      = "ok"
+	statusError   = "error"
+)
+
+// actionKind is what the model asks its driver to do in response to a key.
+type actionKind int
+
+const (
+	actionNone actionKind = iota
+	actionRun
+	actionCancel
+	actionQuit
+)
+
+type action struct {
+	kind actionKind
+
+	// set for actionRun
+	day   int // index in the model's days
+	path  string
+	parts []int
+}
+
+// dayState holds what is known about a single day in the UI.
+type dayState struct {
+	entry  solutions.Entry
+	inputs []string // candidate input files, the first being the default
+	input  int      // index of the selected input file
+
+	status   string
+	duration time.Duration
+	output   []string
+	scroll   int // index of the first output line shown
+}
+
+// model is the state of the UI, independent from any terminal.
+type model struct {
+	days     []*dayState
+	selected int
+	running  bool
+}
+
+func newModel(entries []solutions.Entry, inputs func(entry solutions.Entry) []string) *model {
+	m := &model{}
+	for _, entry := range entries {
+		m.days = append(m.days, &dayState{entry: entry, inputs: inputs(entry)})
+	}
+
+	return m
+}
+
+// handleKey updates the model for the given key, returning the action the driver should
+// take as a result.
+func (m *model) handleKey(key string) action {
+	if len(m.days) == 0 {
+		if key == "q" || key == keyCtrlC {
+			return action{kind: actionQuit}
+		}
+
+		return action{}
+	}
+
+	day := m.days[m.selected]
+
+	switch key {
+	case "q", keyCtrlC:
+		return action{kind: actionQuit}
+	case keyEscape, "x":
+		if m.running {
+			return action{kind: actionCancel}
+		}
+	case keyUp, "k":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case keyDown, "j":
+		if m.selected < len(m.days)-1 {
+			m.selected++
+		}
+	case keyPageUp, "K":
+		day.scroll -= 10
+		if day.scroll < 0 {
+			day.scroll = 0
+		}
+	case keyPageDown, "J":
+		day.scroll += 10
+		if max := len(day.output) - 1; day.scroll > max {
+			day.scroll = max
+		}
+
+		if day.scroll < 0 {
+			day.scroll = 0
+		}
+	case keyTab, "i":
+		if len(day.inputs) > 0 {
+			day.input = (day.input + 1) % len(day.inputs)
+		}
+	case keyEnter, "r", "1", "2":
+		if m.running || len(day.inputs) == 0 {
+			return action{}
+		}
+
+		var parts []int
+		if key == "1" || key == "2" {
+			parts = []int{int(key[0] - '0')}
+		}
+
+		m.running = true
+		day.status = statusRunning
+
+		return action{kind: actionRun, day: m.selected, path: day.inputs[day.input], parts: parts}
+	}
+
+	return action{}
+}
+
+// finish records the outcome of a run started by an actionRun for the given day.
+func (m *model) finish(day int, output []string, duration time.Duration, failed bool) {
+	m.running = false
+
+	state := m.days[day]
+	state.output, state.duration, state.scroll = output, duration, 0
+
+	state.status = statusOK
+	if failed {
+		state.status = statusError
+	}
+}
+
+// view renders the model as lines fitting in a terminal of the given size.
+func (m *model) view(width, height int) (lines []string) {
+	lines = append(lines, "Advent of Code")
+
+	// split the screen between the list of days & the selected day's output
+	listHeight := len(m.days)
+	if max := (height - 4) / 2; listHeight > max {
+		listHeight = max
+	}
+
+	first := m.selected - listHeight/2
+	if first > len(m.days)-listHeight {
+		first = len(m.days) - listHeight
+	}
+
+	if first < 0 {
+		first = 0
+	}
+
+	for i := first; i < first+listHeight && i < len(m.days); i++ {
+		cursor := " "
+		if i == m.selected {
+			cursor = ">"
+		}
+
+		lines = append(lines, m.days[i].summary(cursor))
+	}
+
+	if len(m.days) == 0 {
+		lines = append(lines, "  no solutions registered")
+	}
+
+	lines = append(lines, strings.Repeat("-", width))
+
+	outputHeight := height - len(lines) - 1
+	if len(m.days) > 0 {
+		day := m.days[m.selected]
+		for i := day.scroll; i < len(day.output) && i < day.scroll+outputHeight; i++ {
+			lines = append(lines, day.output[i])
+		}
+	}
+
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, "↑/↓ select  enter run  1/2 run part  tab input  J/K scroll  x cancel  q quit")
+
+	for i := range lines {
+		lines[i] = truncate(lines[i], width)
+	}
+
+	return lines
+}
+
+func (d *dayState) summary(cursor string) string {
+	input := "(no input)"
+	if len(d.inputs) > 0 {
+		input = filepath.Base(d.inputs[d.input])
+	}
+
+	duration := ""
+	if d.status == statusOK || d.status == statusError {
+		duration = d.duration.Round(time.Microsecond).String()
+	}
+
+	return fmt.Sprintf("%s %4d day %-2d  %-24s  %-7s  %10s  %s", cursor, d.entry.Year, d.entry.Day, truncate(d.entry.Metadata.Title, 24), d.status, duration, input)
+}
+
+// truncate shortens s to at most width runes.
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+
+	return string(runes[:width])
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+)
+
+func testModel() *model {
+	entries := []solutions.Entry{
+		{Year: 2020, Day: 1, Metadata: solutions.Metadata{Title: "Report Repair"}},
+		{Year: 2020, Day: 2, Metadata: solutions.Metadata{Title: "Password Philosophy"}},
+	}
+
+	return newModel(entries, func(entry solutions.Entry) []string {
+		if entry.Day == 2 {
+			return nil
+		}
+
+		return []string{"inputs/2020/day1/input", "inputs/2020/day1/example1"}
+	})
+}
+
+func TestModelHandleKey(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		keys []string
+
+		// outputs
+		expectedAction   action
+		expectedSelected int
+		expectedStatus   string // of the selected day
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		m := testModel()
+
+		var got action
+		for _, key := range cfg.keys {
+			got = m.handleKey(key)
+		}
+
+		if diff := cmp.Diff(cfg.expectedAction, got, cmp.AllowUnexported(action{})); diff != "" {
+			t.Errorf("Unexpected action (-expected +got):\n%s", diff)
+		}
+
+		if m.selected != cfg.expectedSelected {
+			t.Errorf("Got %v, expected %v", m.selected, cfg.expectedSelected)
+		}
+
+		if status := m.days[m.selected].status; status != cfg.expectedStatus {
+			t.Errorf("Got %q, expected %q", status, cfg.expectedStatus)
+		}
+	}
+
+	tests := map[string]Test{
+		"run": {
+			keys:           []string{keyEnter},
+			expectedAction: action{kind: actionRun, day: 0, path: "inputs/2020/day1/input"},
+			expectedStatus: statusRunning,
+		},
+		"run part on example": {
+			keys:           []string{keyTab, "2"},
+			expectedAction: action{kind: actionRun, day: 0, path: "inputs/2020/day1/example1", parts: []int{2}},
+			expectedStatus: statusRunning,
+		},
+		"run while running": {
+			keys:           []string{keyEnter, "1"},
+			expectedStatus: statusRunning,
+		},
+		"cancel": {
+			keys:           []string{keyEnter, keyEscape},
+			expectedAction: action{kind: actionCancel},
+			expectedStatus: statusRunning,
+		},
+		"select": {
+			keys:             []string{keyDown, keyDown, keyUp, "j"},
+			expectedSelected: 1,
+		},
+		"run without input": {
+			keys:             []string{keyDown, keyEnter},
+			expectedSelected: 1,
+		},
+		"quit": {
+			keys:           []string{"q"},
+			expectedAction: action{kind: actionQuit},
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+func TestModelView(t *testing.T) {
+	t.Parallel()
+
+	m := testModel()
+	m.handleKey(keyEnter)
+	m.finish(0, []string{"PART 1", "  RESULT: 42"}, 1500*time.Microsecond, false)
+
+	expected := []string{
+		"Advent of Code",
+		"> 2020 day 1   Report Repair             ok            1.5ms  input",
+		"  2020 day 2   Password Philosophy                            (no input)",
+		strings.Repeat("-", 72),
+		"PART 1",
+		"  RESULT: 42",
+		"",
+		"↑/↓ select  enter run  1/2 run part  tab input  J/K scroll  x cancel  q ",
+	}
+
+	if diff := cmp.Diff(expected, m.view(72, 8)); diff != "" {
+		t.Errorf("Unexpected view (-expected +got):\n%s", diff)
+	}
+}
+
+func TestDecodeKeys(t *testing.T) {
+	t.Parallel()
+
+	got := decodeKeys([]byte("j\x1b[A\r\t\x1b[6~q\x03\x1b"))
+	expected := []string{"j", keyUp, keyEnter, keyTab, keyPageDown, "q", keyCtrlC, keyEscape}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Unexpected keys (-expected +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,221 @@
+// Package tui implements an interactive terminal UI for browsing & running solutions.
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/segwin/adventofcode-2020/internal/logging"
+	"github.com/segwin/adventofcode-2020/internal/solutions"
+	"golang.org/x/term"
+)
+
+var (
+	ErrNotTerminal = errors.New("not a terminal")
+)
+
+// redrawInterval is how often the screen is redrawn while idle, e.g. to pick up
+// terminal resizes.
+const redrawInterval = 250 * time.Millisecond
+
+// Config describes what the UI can run.
+type Config struct {
+	// Entries are the solutions listed in the UI.
+	Entries []solutions.Entry
+
+	// Inputs returns the candidate input files for the given solution. The first one is
+	// selected by default.
+	Inputs func(entry solutions.Entry) []string
+
+	// Solve solves the given parts (or all parts if none are given) of a solution using
+	// the input file at path.
+	Solve func(ctx context.Context, solution solutions.Solution, path string, parts ...int) (solutions.Result, time.Duration, error)
+
+	// Render writes a human-readable rendering of a result to w, reporting any errors to
+	// logger.
+	Render func(w io.Writer, logger *logging.Logger, result solutions.Result)
+}
+
+type runResult struct {
+	day      int
+	output   []string
+	duration time.Duration
+	failed   bool
+}
+
+// Run shows the UI on the given terminal until the user quits or ctx is done.
+func Run(ctx context.Context, in *os.File, out io.Writer, cfg Config) error {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("%w (%s)", ErrNotTerminal, in.Name())
+	}
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to set up terminal (%w)", err)
+	}
+
+	defer term.Restore(fd, oldState)
+
+	// use the alternate screen & hide the cursor while running
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+
+	keys := make(chan string)
+	go readKeys(in, keys)
+
+	m := newModel(cfg.Entries, cfg.Inputs)
+	results := make(chan runResult, 1)
+	cancelRun := func() {}
+	defer func() { cancelRun() }()
+
+	ticker := time.NewTicker(redrawInterval)
+	defer ticker.Stop()
+
+	for {
+		width, height, err := term.GetSize(fd)
+		if err != nil {
+			width, height = 80, 24
+		}
+
+		draw(out, m.view(width, height))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case r := <-results:
+			m.finish(r.day, r.output, r.duration, r.failed)
+		case key, ok := <-keys:
+			if !ok {
+				return nil // input closed
+			}
+
+			a := m.handleKey(key)
+			switch a.kind {
+			case actionQuit:
+				return nil
+			case actionCancel:
+				cancelRun()
+			case actionRun:
+				runCtx, cancel := context.WithCancel(ctx)
+				cancelRun = cancel
+
+				solution := m.days[a.day].entry.Solution
+				go func() {
+					defer cancel()
+					results <- run(runCtx, cfg, solution, a)
+				}()
+			}
+		}
+	}
+}
+
+// run solves the parts requested by the given action, capturing the rendered output
+// along with everything logged by the solution.
+func run(ctx context.Context, cfg Config, solution solutions.Solution, a action) runResult {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "INPUT: %s\n", a.path)
+
+	logger := logging.New(&buf, logging.Debug)
+	result, duration, err := cfg.Solve(logging.WithLogger(ctx, logger), solution, a.path, a.parts...)
+
+	failed := err != nil
+	if err != nil {
+		fmt.Fprintf(&buf, "\nERROR: %v\n", err)
+	} else {
+		cfg.Render(&buf, logger, result)
+		for _, part := range result.Parts {
+			failed = failed || part.Err != nil
+		}
+	}
+
+	fmt.Fprintf(&buf, "\nTIME: %s\n", duration.Round(time.Microsecond))
+
+	return runResult{
+		day:      a.day,
+		output:   strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"),
+		duration: duration,
+		failed:   failed,
+	}
+}
+
+// draw replaces the screen's contents with the given lines.
+func draw(out io.Writer, lines []string) {
+	// raw mode doesn't translate newlines, so carriage returns are needed
+	fmt.Fprint(out, "\x1b[H\x1b[2J"+strings.Join(lines, "\r\n"))
+}
+
+// readKeys decodes the keys read from r and sends them to keys, until r fails.
+func readKeys(r io.Reader, keys chan<- string) {
+	defer close(keys)
+
+	buf := make([]byte, 16)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+
+		for _, key := range decodeKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// escapeSequences maps the terminal escape sequences of special keys to key names.
+var escapeSequences = map[string]string{
+	"\x1b[A":  keyUp,
+	"\x1b[B":  keyDown,
+	"\x1bOA":  keyUp,
+	"\x1bOB":  keyDown,
+	"\x1b[5~": keyPageUp,
+	"\x1b[6~": keyPageDown,
+}
+
+// decodeKeys splits raw terminal input into key names. Unknown escape sequences are
+// dropped.
+func decodeKeys(input []byte) (keys []string) {
+	for len(input) > 0 {
+		if input[0] == '\x1b' {
+			if len(input) == 1 {
+				return append(keys, keyEscape)
+			}
+
+			matched := false
+			for sequence, key := range escapeSequences {
+				if bytes.HasPrefix(input, []byte(sequence)) {
+					keys, input, matched = append(keys, key), input[len(sequence):], true
+					break
+				}
+			}
+
+			if !matched {
+				return keys // unknown sequence, drop the rest of this read
+			}
+
+			continue
+		}
+
+		switch input[0] {
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		case '\t':
+			keys = append(keys, keyTab)
+		case 3:
+			keys = append(keys, keyCtrlC)
+		default:
+			keys = append(keys, string(input[0]))
+		}
+
+		input = input[1:]
+	}
+
+	return keys
+}
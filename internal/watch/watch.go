@@ -0,0 +1,92 @@
+// Package watch detects changes to files by polling them.
+package watch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultInterval is the default time between two polls.
+const DefaultInterval = 500 * time.Millisecond
+
+// fileState is what is compared between polls to detect a change to a file.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// Snapshot records the state of a set of files at a point in time.
+type Snapshot map[string]fileState
+
+// Take returns a snapshot of the given paths. Directories are walked recursively, and
+// paths that don't exist are recorded as such so that their creation is detected.
+func Take(paths ...string) (Snapshot, error) {
+	snapshot := Snapshot{}
+
+	for _, root := range paths {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil // recorded as missing
+			} else if err != nil {
+				return err
+			}
+
+			if !info.IsDir() {
+				snapshot[path] = fileState{size: info.Size(), modTime: info.ModTime()}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+// Changed returns the paths of files that were created, modified or removed between
+// this snapshot & the given later one.
+func (s Snapshot) Changed(later Snapshot) (paths []string) {
+	for path, state := range later {
+		if previous, ok := s[path]; !ok || previous.size != state.size || !previous.modTime.Equal(state.modTime) {
+			paths = append(paths, path)
+		}
+	}
+
+	for path := range s {
+		if _, ok := later[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// Wait polls the given paths every interval until one of them changes compared to since,
+// returning the new snapshot along with the paths that changed. If ctx is done first,
+// its error is returned.
+func Wait(ctx context.Context, since Snapshot, interval time.Duration, paths ...string) (Snapshot, []string, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, err := Take(paths...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if changed := since.Changed(current); len(changed) > 0 {
+			return current, changed, nil
+		}
+	}
+}
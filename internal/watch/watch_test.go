@@ -0,0 +1,147 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSnapshotChanged(t *testing.T) {
+	t.Parallel()
+
+	type Test struct {
+		// inputs
+		change func(t *testing.T, dir string)
+
+		// outputs
+		expectedChanged []string // relative to the watched directory
+	}
+
+	testFn := func(t *testing.T, cfg Test) {
+		t.Parallel()
+
+		dir, err := ioutil.TempDir("", "aoc-watch-test")
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		writeFile(t, filepath.Join(dir, "solution.go"), "package day1\n")
+		writeFile(t, filepath.Join(dir, "sub", "values.go"), "package day1\n")
+
+		before, err := Take(dir, filepath.Join(dir, "input"))
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		cfg.change(t, dir)
+
+		after, err := Take(dir, filepath.Join(dir, "input"))
+		if err != nil {
+			t.Fatalf("Got %v, expected nil", err)
+		}
+
+		var got []string
+		for _, path := range before.Changed(after) {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				t.Fatalf("Got %v, expected nil", err)
+			}
+
+			got = append(got, rel)
+		}
+
+		sort.Strings(got)
+
+		if diff := cmp.Diff(cfg.expectedChanged, got); diff != "" {
+			t.Errorf("Unexpected changes (-expected +got):\n%s", diff)
+		}
+	}
+
+	tests := map[string]Test{
+		"unchanged": {
+			change: func(t *testing.T, dir string) {},
+		},
+		"modified": {
+			change: func(t *testing.T, dir string) {
+				writeFile(t, filepath.Join(dir, "sub", "values.go"), "package day1\n\ntype Value int\n")
+			},
+			expectedChanged: []string{"sub/values.go"},
+		},
+		"created & removed": {
+			change: func(t *testing.T, dir string) {
+				writeFile(t, filepath.Join(dir, "input"), "1\n")
+				if err := os.Remove(filepath.Join(dir, "solution.go")); err != nil {
+					t.Fatalf("Got %v, expected nil", err)
+				}
+			},
+			expectedChanged: []string{"input", "solution.go"},
+		},
+	}
+
+	for name, cfg := range tests {
+		cfg := cfg
+		t.Run(name, func(t *testing.T) { testFn(t, cfg) })
+	}
+}
+
+func TestWait(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "aoc-watch-test")
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "input")
+	since, err := Take(path)
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		writeFile(t, path, "1\n")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	current, changed, err := Wait(ctx, since, time.Millisecond, path)
+	if err != nil {
+		t.Fatalf("Got %v, expected nil", err)
+	}
+
+	if diff := cmp.Diff([]string{path}, changed); diff != "" {
+		t.Errorf("Unexpected changes (-expected +got):\n%s", diff)
+	}
+
+	// nothing changes after this
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, _, err := Wait(ctx, current, time.Millisecond, path); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Got %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Errorf("Got %v, expected nil", err)
+		return
+	}
+
+	if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Errorf("Got %v, expected nil", err)
+	}
+}
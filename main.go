@@ -1,15 +1,42 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/segwin/adventofcode-2020/cmd"
+	"github.com/segwin/adventofcode-2020/internal/logging"
 )
 
 func main() {
-	if err := cmd.New("aoc").Execute(); err != nil {
-		fmt.Printf("Error: %v", err)
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+func run() error {
+	cfg, err := cmd.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// cancel the context on the first interrupt so that commands can clean up & exit
+	// gracefully, while a second one kills the process as usual
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		signal.Stop(signals)
+		cancel()
+	}()
+
+	ctx = logging.WithLogger(ctx, logging.New(os.Stderr, logging.Info))
+	return cmd.New("aoc", cfg).ExecuteContext(ctx)
+}